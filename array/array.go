@@ -6,24 +6,52 @@ package array
 
 import (
 	"fmt"
-	"sort"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strings"
 )
 
-// Array is a alternative structure for the default array implementation
+// Array is a alternative structure for the default array implementation.
+// Values are stored in a growable ring buffer, so adding or removing values
+// at both ends of the array is amortized O(1)
 type Array struct {
-	array []interface{}
+	buffer []interface{}
+	head   int
+	length int
+	// reserved is the capacity the buffer never shrinks below
+	reserved int
 }
 
 // ArrayInterface represents all methods of an array
+//
+// Push, Pop, Shift, Unshift, Splice, Fill, CopyWithin and the ...InPlace
+// methods mutate the array. Reverse, Sort, Slice, Concat, Map, Filter,
+// With and the To... methods return a new array and leave the receiver untouched.
 type ArrayInterface interface {
 	Push(values ...interface{}) int
 	Pop() interface{}
 	At(int) interface{}
+	TryAt(int) (interface{}, bool)
+	AtE(int) (interface{}, error)
+	TryPop() (interface{}, bool)
+	PopE() (interface{}, error)
 	Length() int
+	Cap() int
+	Grow(n int)
+	Clip()
+	Clear()
+	Truncate(n int)
 	Shift() interface{}
+	TryShift() (interface{}, bool)
+	ShiftE() (interface{}, error)
 	Unshift(values ...interface{}) int
 	Filter(func(interface{}, int) bool) ArrayInterface
 	ForEach(func(interface{}, int))
+	All() iter.Seq2[int, interface{}]
+	Values() iter.Seq[interface{}]
+	Backward() iter.Seq2[int, interface{}]
 	Reduce(func(interface{}, interface{}, int) interface{}, interface{}) interface{}
 	ReduceRight(func(interface{}, interface{}, int) interface{}, interface{}) interface{}
 	Map(func(interface{}, int) interface{}) ArrayInterface
@@ -32,10 +60,48 @@ type ArrayInterface interface {
 	Some(func(interface{}, int) bool) bool
 	Every(func(interface{}, int) bool) bool
 	Reverse() ArrayInterface
+	ReverseInPlace() ArrayInterface
+	ToReversed() ArrayInterface
+	ShuffleInPlace(rand.Source) ArrayInterface
+	ToShuffled(rand.Source) ArrayInterface
+	ToSorted(Comparator) ArrayInterface
+	ToSpliced(start int, deleteCount int, items ...interface{}) ArrayInterface
+	With(index int, value interface{}) (ArrayInterface, error)
 	Concat(arrays ...ArrayInterface) ArrayInterface
 	Sort(func(a, b interface{}) bool) ArrayInterface
+	SortInPlace(Comparator) ArrayInterface
+	SortStable(Comparator) ArrayInterface
 	IndexOf(interface{}, int) int
 	LastIndexOf(interface{}, int) int
+	Includes(interface{}) bool
+	IndexOfFunc(interface{}, int, EqualityFunc) int
+	IncludesFunc(interface{}, EqualityFunc) bool
+	Equal(ArrayInterface) bool
+	Compare(ArrayInterface, Comparator) int
+	IsSorted(Comparator) bool
+	BinarySearch(interface{}, Comparator) (int, bool)
+	LowerBound(interface{}, Comparator) int
+	UpperBound(interface{}, Comparator) int
+	InsertSorted(interface{}, Comparator) int
+	RemoveSorted(interface{}, Comparator) int
+	MergeSorted(ArrayInterface, Comparator) ArrayInterface
+	Unique(...EqualityFunc) ArrayInterface
+	UniqueBy(func(interface{}) interface{}, ...EqualityFunc) ArrayInterface
+	Union(ArrayInterface, ...EqualityFunc) ArrayInterface
+	Intersect(ArrayInterface, ...EqualityFunc) ArrayInterface
+	Difference(ArrayInterface, ...EqualityFunc) ArrayInterface
+	SymmetricDifference(ArrayInterface, ...EqualityFunc) ArrayInterface
+	Find(func(interface{}, int) bool) (interface{}, bool)
+	FindIndex(func(interface{}, int) bool) int
+	FindLast(func(interface{}, int) bool) (interface{}, bool)
+	FindLastIndex(func(interface{}, int) bool) int
+	Fill(value interface{}, startAndEnd ...int) ArrayInterface
+	CopyWithin(target int, startAndEnd ...int) ArrayInterface
+	Flat(depth int) ArrayInterface
+	FlatMap(func(interface{}, int) interface{}) ArrayInterface
+	Join(separator string) string
+	Keys() ArrayInterface
+	Entries() ArrayInterface
 	String() string
 	ArrayInterface() []interface{}
 }
@@ -43,67 +109,139 @@ type ArrayInterface interface {
 // New returns a new array
 func New(values ...interface{}) ArrayInterface {
 	array := &Array{}
-	for i := 0; i < len(values); i++ {
-		array.Push(values[i])
-	}
+	array.Push(values...)
 	return ArrayInterface(array)
 
 }
 
-// At get a value at index
+// NewWithCapacity returns a new empty array with room for capacity elements.
+// The array doesn't release memory below that capacity until Clip is called
+func NewWithCapacity(capacity int) ArrayInterface {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &Array{buffer: make([]interface{}, capacity), reserved: capacity}
+}
+
+// At get a value at index, a negative index counts back from the end of the array.
+// Returns nil if index is out of range
 func (a *Array) At(index int) interface{} {
-	var result interface{}
-	if index < len(a.array) && index >= 0 {
-		result = a.array[index]
+	value, _ := a.TryAt(index)
+	return value
+}
+
+// TryAt get a value at index, a negative index counts back from the end of the array.
+// ok is false if index is out of range
+func (a *Array) TryAt(index int) (value interface{}, ok bool) {
+	if index < 0 {
+		index += a.length
 	}
-	return result
+	if index < a.length && index >= 0 {
+		return a.get(index), true
+	}
+	return nil, false
+}
+
+// AtE get a value at index, returns an *IndexError if index is out of range
+func (a *Array) AtE(index int) (interface{}, error) {
+	if value, ok := a.TryAt(index); ok {
+		return value, nil
+	}
+	return nil, &IndexError{Index: index, Length: a.length}
 }
 
 // Push put values at the end of array
 // returns the number of values added
 func (a *Array) Push(values ...interface{}) int {
-	a.array = append(a.array, values...)
+	a.grow(len(values))
+	for _, value := range values {
+		a.buffer[a.index(a.length)] = value
+		a.length++
+	}
 	return len(values)
 }
 
 // Pop remove the last value of the array
 func (a *Array) Pop() interface{} {
 	var result interface{}
-	if len(a.array) > 0 {
-		result = a.array[len(a.array)-1]
-		a.array = a.array[:len(a.array)-1]
+	if a.length > 0 {
+		last := a.index(a.length - 1)
+		result = a.buffer[last]
+		a.buffer[last] = nil
+		a.length--
+		a.shrink()
 	}
 	return result
 }
 
+// TryPop remove the last value of the array, ok is false if the array is empty
+func (a *Array) TryPop() (value interface{}, ok bool) {
+	if a.length == 0 {
+		return nil, false
+	}
+	return a.Pop(), true
+}
+
+// PopE remove the last value of the array, returns ErrEmpty if the array is empty
+func (a *Array) PopE() (interface{}, error) {
+	if value, ok := a.TryPop(); ok {
+		return value, nil
+	}
+	return nil, ErrEmpty
+}
+
 // Length returns the number of elements of the array
 func (a *Array) Length() int {
-	return len(a.array)
+	return a.length
 }
 
 // Shift removes the first element of the array and returns it
 func (a *Array) Shift() interface{} {
 	var result interface{}
-	if len(a.array) > 0 {
-		result = a.array[0]
+	if a.length > 0 {
+		result = a.buffer[a.head]
+		a.buffer[a.head] = nil
+		a.head = a.index(1)
+		a.length--
+		a.shrink()
 	}
-	a.array = a.array[1:]
 	return result
 }
 
+// TryShift removes the first element of the array and returns it,
+// ok is false if the array is empty
+func (a *Array) TryShift() (value interface{}, ok bool) {
+	if a.length == 0 {
+		return nil, false
+	}
+	return a.Shift(), true
+}
+
+// ShiftE removes the first element of the array and returns it,
+// returns ErrEmpty if the array is empty
+func (a *Array) ShiftE() (interface{}, error) {
+	if value, ok := a.TryShift(); ok {
+		return value, nil
+	}
+	return nil, ErrEmpty
+}
+
 // Unshift add elements at index 0 and returns the number of added elements
+// values are inserted one after the other so Unshift(1, 2) on [3] gives [2, 1, 3]
 func (a *Array) Unshift(values ...interface{}) int {
-
+	a.grow(len(values))
 	for _, value := range values {
-		a.array = append(append([]interface{}{}, value), a.array...)
+		a.head = a.index(len(a.buffer) - 1)
+		a.buffer[a.head] = value
+		a.length++
 	}
 	return len(values)
 }
 
 // ForEach execute callback on each element of the array
 func (a *Array) ForEach(callback func(value interface{}, i int)) {
-	for i := 0; i < len(a.array); i++ {
-		callback(a.array[i], i)
+	for i := 0; i < a.length; i++ {
+		callback(a.get(i), i)
 	}
 }
 
@@ -118,7 +256,7 @@ func (a *Array) Reduce(callback func(result interface{}, value interface{}, inde
 
 func (a *Array) ReduceRight(callback func(result interface{}, value interface{}, index int) interface{}, initial interface{}) interface{} {
 	result := initial
-	for i := len(a.array) - 1; i >= 0; i-- {
+	for i := a.length - 1; i >= 0; i-- {
 		result = callback(result, a.At(i), i)
 	}
 	return result
@@ -132,35 +270,38 @@ func (a *Array) Map(callback func(value interface{}, i int) interface{}) ArrayIn
 	}, New()).(ArrayInterface)
 }
 
-// Sort sorts an array given a compare function
-func (a *Array) Sort(compareFunc func(a, b interface{}) bool) ArrayInterface {
-	result := a.Slice()
-	sort.Sort(&sorter{result, compareFunc})
-	return result
-}
-
 // Splice remove elements from the array at a given index and optionally insert new elements
+// A negative start counts back from the end of the array.
+// Elements after the removed ones are moved with a single copy
 func (a *Array) Splice(start int, deleteCount int, items ...interface{}) ArrayInterface {
-	var result ArrayInterface
-	head := a.Slice(0, start)
-	queue := a.Slice(start + deleteCount)
-	result = a.Slice(start, start+deleteCount)
-	a.array = []interface{}{}
-	for i := 0; i < head.Length(); i++ {
-		a.Push(head.At(i))
-	}
-	a.Push(items...)
-	for i := 0; i < queue.Length(); i++ {
-		a.Push(queue.At(i))
+	start = relativeIndex(start, a.length)
+	if deleteCount < 0 {
+		deleteCount = 0
 	}
-	return result
+	if deleteCount > a.length-start {
+		deleteCount = a.length - start
+	}
+	removed := a.slice(start, start+deleteCount)
+	length := a.length + len(items) - deleteCount
+	a.grow(length - a.length)
+	if a.head+max(a.length, length) > len(a.buffer) {
+		a.resize(len(a.buffer))
+	}
+	values := a.buffer[a.head : a.head+max(a.length, length)]
+	copy(values[start+len(items):], values[start+deleteCount:a.length])
+	copy(values[start:], items)
+	for i := length; i < a.length; i++ {
+		values[i] = nil
+	}
+	a.length = length
+	a.shrink()
+	return removed
 }
 
 // Slice returns a copy of a portion of the array
 // It takes up to 2 arguments :
-// 	- begin int
-// 	- end int (excluded)
-//
+//   - begin int
+//   - end int (excluded)
 func (a *Array) Slice(beginAndEndValues ...int) ArrayInterface {
 	var begin, end int
 	values := beginAndEndValues
@@ -172,7 +313,7 @@ func (a *Array) Slice(beginAndEndValues ...int) ArrayInterface {
 			end = a.Length()
 		}
 	} else {
-		return New(a.array...)
+		return a.slice(0, a.length)
 	}
 	if begin < 0 {
 		if a.Length()+begin < 0 {
@@ -188,17 +329,20 @@ func (a *Array) Slice(beginAndEndValues ...int) ArrayInterface {
 		end = a.Length() + end
 
 	}
+	if end > a.length {
+		end = a.length
+	}
 	if end <= begin {
 		return New()
 	}
 
-	return New(a.array[begin:end]...)
+	return a.slice(begin, end)
 }
 
 // Some returns true if the callback predicate is satisfied
 func (a *Array) Some(callback func(v interface{}, index int) bool) bool {
-	for i, v := range a.array {
-		if callback(v, i) {
+	for i := 0; i < a.length; i++ {
+		if callback(a.get(i), i) {
 			return true
 		}
 	}
@@ -207,8 +351,8 @@ func (a *Array) Some(callback func(v interface{}, index int) bool) bool {
 
 // Every returns true if the callback predicate is true for every element of the array
 func (a *Array) Every(callback func(v interface{}, index int) bool) bool {
-	for i, value := range a.array {
-		if !callback(value, i) {
+	for i := 0; i < a.length; i++ {
+		if !callback(a.get(i), i) {
 			return false
 		}
 	}
@@ -219,23 +363,76 @@ func (a *Array) Every(callback func(v interface{}, index int) bool) bool {
 func (a *Array) Reverse() ArrayInterface {
 	var result ArrayInterface = New()
 	for i := a.Length() - 1; i >= 0; i-- {
-		result.Push(a.array[i])
+		result.Push(a.get(i))
 	}
 	return result
 }
 
+// ReverseInPlace reverse the order of the elements of the array and returns it
+func (a *Array) ReverseInPlace() ArrayInterface {
+	for i, j := 0, a.length-1; i < j; i, j = i+1, j-1 {
+		x, y := a.index(i), a.index(j)
+		a.buffer[x], a.buffer[y] = a.buffer[y], a.buffer[x]
+	}
+	return a
+}
+
+// ToReversed returns a reversed copy of the array, same as Reverse
+func (a *Array) ToReversed() ArrayInterface {
+	return a.Reverse()
+}
+
+// ToSpliced returns a copy of the array where deleteCount elements at start
+// are replaced with items, the receiver is left untouched
+func (a *Array) ToSpliced(start int, deleteCount int, items ...interface{}) ArrayInterface {
+	result := a.slice(0, a.length)
+	result.Splice(start, deleteCount, items...)
+	return result
+}
+
+// With returns a copy of the array where the element at index is replaced with value.
+// A negative index counts back from the end of the array.
+// Returns an *IndexError if index is out of range
+func (a *Array) With(index int, value interface{}) (ArrayInterface, error) {
+	if index < 0 {
+		index += a.length
+	}
+	if index < 0 || index >= a.length {
+		return nil, &IndexError{Index: index, Length: a.length}
+	}
+	result := a.slice(0, a.length)
+	result.set(index, value)
+	return result, nil
+}
+
 // Concat adds arrays to the end of the array and returns an new array
 func (a *Array) Concat(arrays ...ArrayInterface) ArrayInterface {
-	result := New(a.array...)
+	length := a.length
+	for _, array := range arrays {
+		length += array.Length()
+	}
+	result := &Array{buffer: make([]interface{}, length)}
+	appendArray := func(array *Array) {
+		result.grow(array.length)
+		result.length += array.copyTo(result.buffer[result.length:], 0, array.length)
+	}
+	appendArray(a)
 	for _, array := range arrays {
-		array.ForEach(func(val interface{}, i int) {
-			result.Push(val)
-		})
+		switch array := array.(type) {
+		case *Array:
+			appendArray(array)
+		case *SyncArray:
+			appendArray(array.Snapshot())
+		default:
+			for value := range array.Values() {
+				result.Push(value)
+			}
+		}
 	}
 	return result
 }
 
-//Filter filters elements given a predicate
+// Filter filters elements given a predicate
 func (a *Array) Filter(predicate func(interface{}, int) bool) ArrayInterface {
 	return a.Reduce(func(result interface{}, el interface{}, index int) interface{} {
 		if predicate(el, index) {
@@ -245,10 +442,12 @@ func (a *Array) Filter(predicate func(interface{}, int) bool) ArrayInterface {
 	}, New()).(ArrayInterface)
 }
 
+// IndexOf returns the first index at which searchElement can be found, starting at fromIndex,
+// or -1 if it is not present. A negative fromIndex counts back from the end of the array.
+// Elements are compared with StrictEqual
 func (a *Array) IndexOf(searchElement interface{}, fromIndex int) int {
-
-	for i := fromIndex; i < a.Length(); i++ {
-		if a.At(i) == searchElement {
+	for i := relativeIndex(fromIndex, a.length); i < a.length; i++ {
+		if StrictEqual(a.get(i), searchElement) {
 			return i
 		}
 	}
@@ -257,9 +456,16 @@ func (a *Array) IndexOf(searchElement interface{}, fromIndex int) int {
 
 // The lastIndexOf() method returns the last index at which a given element
 // can be found in the array, or -1 if it is not present. The array is searched backwards, starting at fromIndex.
+// A negative fromIndex counts back from the end of the array
 func (a *Array) LastIndexOf(searchElement interface{}, fromIndex int) int {
+	if fromIndex < 0 {
+		fromIndex += a.length
+	}
+	if fromIndex >= a.length {
+		fromIndex = a.length - 1
+	}
 	for i := fromIndex; i >= 0; i-- {
-		if a.At(i) == searchElement {
+		if StrictEqual(a.get(i), searchElement) {
 			return i
 		}
 	}
@@ -267,141 +473,330 @@ func (a *Array) LastIndexOf(searchElement interface{}, fromIndex int) int {
 	return -1
 }
 
-func (a *Array) ArrayInterface() []interface{} {
-	return append([]interface{}{}, a.array...)
+// Includes returns true if searchElement is in the array.
+// Unlike IndexOf, NaN is found
+func (a *Array) Includes(searchElement interface{}) bool {
+	searchNaN := isNaN(searchElement)
+	for i := 0; i < a.length; i++ {
+		value := a.get(i)
+		if StrictEqual(value, searchElement) || searchNaN && isNaN(value) {
+			return true
+		}
+	}
+	return false
 }
 
-func (a *Array) String() string {
-	return "ArrayInterface[" + a.Reduce(func(result interface{}, el interface{}, index int) interface{} {
-		if index == a.Length()-1 {
-			return result.(string) + fmt.Sprintf("%+v", el)
-		}
-		return result.(string) + fmt.Sprintf("%+v", el) + ", "
-	}, "").(string) + "]"
+// Find returns the first element satisfying the predicate, ok is false if there is none
+func (a *Array) Find(predicate func(interface{}, int) bool) (value interface{}, ok bool) {
+	if i := a.FindIndex(predicate); i >= 0 {
+		return a.get(i), true
+	}
+	return nil, false
+}
 
+// FindIndex returns the index of the first element satisfying the predicate or -1
+func (a *Array) FindIndex(predicate func(interface{}, int) bool) int {
+	for i := 0; i < a.length; i++ {
+		if predicate(a.get(i), i) {
+			return i
+		}
+	}
+	return -1
 }
 
-// NewFrom creates an Array from builtin Go arrays
-// support the following types :
-// []Bool, []Int, []int8, []int16, []int32, []int64, []uint,
-// []uint8, []uint16, []uint32, []uint64, []float32, []float64, []complex64, []complex128 , []string
-//
-// CAN PANIC
-func NewFrom(collection interface{}, delegate ...func(interface{}, ArrayInterface) error) ArrayInterface {
-	a := New()
+// FindLast returns the last element satisfying the predicate, ok is false if there is none
+func (a *Array) FindLast(predicate func(interface{}, int) bool) (value interface{}, ok bool) {
+	if i := a.FindLastIndex(predicate); i >= 0 {
+		return a.get(i), true
+	}
+	return nil, false
+}
 
-	switch collection := collection.(type) {
-	case Array:
-		a = collection.Slice().(*Array)
-	case []bool:
-		for _, el := range collection {
-			a.Push(el)
-		}
-	case []int:
-		for _, el := range collection {
-			a.Push(el)
-		}
-	case []int8:
-		for _, el := range collection {
-			a.Push(el)
-		}
-	case []int16:
-		for _, el := range collection {
-			a.Push(el)
-		}
-	case []int32:
-		for _, el := range collection {
-			a.Push(el)
-		}
-	case []int64:
-		for _, el := range collection {
-			a.Push(el)
-		}
-	case []uint:
-		for _, el := range collection {
-			a.Push(el)
+// FindLastIndex returns the index of the last element satisfying the predicate or -1
+func (a *Array) FindLastIndex(predicate func(interface{}, int) bool) int {
+	for i := a.length - 1; i >= 0; i-- {
+		if predicate(a.get(i), i) {
+			return i
 		}
-	case []uint8:
-		for _, el := range collection {
-			a.Push(el)
+	}
+	return -1
+}
+
+// Fill replaces elements from begin to end (excluded) with value and returns the array
+// It takes up to 2 optional arguments, negative values count back from the end :
+//   - begin int, defaults to 0
+//   - end int, defaults to the array length
+func (a *Array) Fill(value interface{}, beginAndEndValues ...int) ArrayInterface {
+	begin, end := a.bounds(beginAndEndValues)
+	for i := begin; i < end; i++ {
+		a.set(i, value)
+	}
+	return a
+}
+
+// CopyWithin copies the elements from begin to end (excluded) to the target index,
+// without changing the array length, and returns the array
+// It takes up to 2 optional arguments, negative values count back from the end :
+//   - begin int, defaults to 0
+//   - end int, defaults to the array length
+func (a *Array) CopyWithin(target int, beginAndEndValues ...int) ArrayInterface {
+	target = relativeIndex(target, a.length)
+	begin, end := a.bounds(beginAndEndValues)
+	if end-begin > a.length-target {
+		end = begin + a.length - target
+	}
+	if end <= begin {
+		return a
+	}
+	values := make([]interface{}, end-begin)
+	a.copyTo(values, begin, end)
+	for i, value := range values {
+		a.set(target+i, value)
+	}
+	return a
+}
+
+// Flat returns a new array with the elements of nested ArrayInterface values
+// concatenated into it, recursively up to depth
+func (a *Array) Flat(depth int) ArrayInterface {
+	result := &Array{}
+	a.flatten(result, depth)
+	return result
+}
+
+// flatten pushes the elements of a into result, spreading nested arrays up to depth
+func (a *Array) flatten(result *Array, depth int) {
+	for i := 0; i < a.length; i++ {
+		value := a.get(i)
+		nested, ok := value.(ArrayInterface)
+		switch {
+		case !ok || depth < 1:
+			result.Push(value)
+		case depth == 1:
+			result.Push(nested.ArrayInterface()...)
+		default:
+			nested.Flat(depth - 1).ForEach(func(value interface{}, i int) {
+				result.Push(value)
+			})
 		}
-	case []uint16:
-		for _, el := range collection {
-			a.Push(el)
+	}
+}
+
+// FlatMap maps each element with callback then flattens the result by 1 level
+func (a *Array) FlatMap(callback func(interface{}, int) interface{}) ArrayInterface {
+	return a.Map(callback).Flat(1)
+}
+
+// Join concatenates the elements of the array, separated by separator.
+// nil elements are empty strings and nested arrays are joined with ","
+func (a *Array) Join(separator string) string {
+	var builder strings.Builder
+	for i := 0; i < a.length; i++ {
+		if i > 0 {
+			builder.WriteString(separator)
 		}
-	case []uint32:
-		for _, el := range collection {
-			a.Push(el)
+		switch value := a.get(i).(type) {
+		case nil:
+		case ArrayInterface:
+			builder.WriteString(value.Join(","))
+		default:
+			fmt.Fprint(&builder, value)
 		}
-	case []uint64:
-		for _, el := range collection {
-			a.Push(el)
+	}
+	return builder.String()
+}
+
+// Keys returns a new array with the indexes of the array
+func (a *Array) Keys() ArrayInterface {
+	result := &Array{}
+	result.grow(a.length)
+	for i := 0; i < a.length; i++ {
+		result.Push(i)
+	}
+	return result
+}
+
+// Entries returns a new array of New(index, value) pairs
+func (a *Array) Entries() ArrayInterface {
+	result := &Array{}
+	result.grow(a.length)
+	for i := 0; i < a.length; i++ {
+		result.Push(New(i, a.get(i)))
+	}
+	return result
+}
+
+// bounds resolves optional begin and end arguments against the array length
+func (a *Array) bounds(beginAndEndValues []int) (begin, end int) {
+	begin, end = 0, a.length
+	if len(beginAndEndValues) > 0 {
+		begin = relativeIndex(beginAndEndValues[0], a.length)
+	}
+	if len(beginAndEndValues) > 1 {
+		end = relativeIndex(beginAndEndValues[1], a.length)
+	}
+	return begin, end
+}
+
+// relativeIndex resolves a possibly negative index against length,
+// clamping the result to [0, length]
+func relativeIndex(index, length int) int {
+	if index < 0 {
+		index += length
+		if index < 0 {
+			return 0
 		}
-	case []float32:
-		for _, el := range collection {
-			a.Push(el)
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
+
+// isNaN returns true if value is a floating point NaN
+func isNaN(value interface{}) bool {
+	switch value := value.(type) {
+	case float64:
+		return math.IsNaN(value)
+	case float32:
+		return math.IsNaN(float64(value))
+	}
+	return false
+}
+
+func (a *Array) ArrayInterface() []interface{} {
+	values := make([]interface{}, a.length)
+	a.copyTo(values, 0, a.length)
+	return values
+}
+
+// String returns a string representation of the array, elements are formatted with %+v
+func (a *Array) String() string {
+	return fmt.Sprintf("%+v", a)
+}
+
+// NewFrom creates an Array from a Go collection. It supports :
+//   - any ArrayInterface, which is copied
+//   - strings, one element per rune
+//   - slices and arrays of any type
+//   - maps, one New(key, value) pair per entry, in unspecified order
+//   - channels, which are drained until closed
+//   - iterators, func(yield func(V) bool) or func(yield func(K, V) bool),
+//     the later producing New(key, value) pairs
+//
+// Other values are handed to delegate if provided, else an error wrapping
+// ErrUnsupportedType is returned.
+func NewFrom(collection interface{}, delegate ...func(interface{}, ArrayInterface) error) (ArrayInterface, error) {
+	switch collection := collection.(type) {
+	case ArrayInterface:
+		if value := reflect.ValueOf(collection); value.Kind() == reflect.Pointer && value.IsNil() {
+			return nil, fmt.Errorf("%w: nil %T", ErrUnsupportedType, collection)
 		}
-	case []float64:
+		return collection.Slice(), nil
+	case string:
+		a := New()
 		for _, el := range collection {
 			a.Push(el)
 		}
-	case []complex64:
-		for _, el := range collection {
-			a.Push(el)
+		return a, nil
+	}
+	a := New()
+	value := reflect.ValueOf(collection)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			a.Push(value.Index(i).Interface())
 		}
-	case []complex128:
-		for _, el := range collection {
-			a.Push(el)
+		return a, nil
+	case reflect.Map:
+		entries := value.MapRange()
+		for entries.Next() {
+			a.Push(New(entries.Key().Interface(), entries.Value().Interface()))
 		}
-	case []struct{}:
-		for _, el := range collection {
-			a.Push(el)
+		return a, nil
+	case reflect.Chan:
+		if value.Type().ChanDir()&reflect.RecvDir == 0 {
+			break
 		}
-	case string:
-		for _, el := range collection {
-			a.Push(el)
+		if value.IsNil() {
+			return a, nil
 		}
-	case []string:
-		for _, el := range collection {
-			a.Push(el)
+		for {
+			el, ok := value.Recv()
+			if !ok {
+				return a, nil
+			}
+			a.Push(el.Interface())
 		}
-	case []interface{}:
-		for _, el := range collection {
-			a.Push(el)
+	case reflect.Func:
+		if value.Type().CanSeq() {
+			if !value.IsNil() {
+				for el := range value.Seq() {
+					a.Push(el.Interface())
+				}
+			}
+			return a, nil
 		}
-	default:
-		if len(delegate) > 0 {
-			if err := delegate[0](collection, a); err != nil {
-				panic(fmt.Sprintf("can't turn value %+v into an ArrayInterface", collection))
+		if value.Type().CanSeq2() {
+			if !value.IsNil() {
+				for key, el := range value.Seq2() {
+					a.Push(New(key.Interface(), el.Interface()))
+				}
 			}
-		} else {
-			panic(fmt.Sprintf("can't turn value %+v into an ArrayInterface", collection))
+			return a, nil
 		}
 	}
-	return a
-}
-
-// sorter is used for array.Sort
-type sorter struct {
-	array       ArrayInterface
-	compareFunc func(a, b interface{}) bool
-}
-
-// Len returns the length of the array
-func (s *sorter) Len() int {
-	return s.array.Length()
+	if len(delegate) > 0 {
+		if err := delegate[0](collection, a); err != nil {
+			return nil, fmt.Errorf("array: can't turn value %+v into an ArrayInterface: %w", collection, err)
+		}
+		return a, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, collection)
 }
 
-// Less compare 2 elements. if i is less than j return true ,else return false
-func (s *sorter) Less(i, j int) bool {
-	if s.compareFunc(s.array.At(i), s.array.At(j)) == true {
-		return true
+// ToSlice copies the elements of the array into the slice pointed by target,
+// replacing its content. nil elements become the zero value of the slice element type.
+// It returns an *ElementTypeError for the first element that can't be assigned
+// to the slice element type, in which case target is left untouched.
+func ToSlice(a ArrayInterface, target interface{}) error {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() || pointer.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: %T is not a pointer to a slice", ErrUnsupportedType, target)
 	}
-	return false
+	sliceType := pointer.Elem().Type()
+	elementType := sliceType.Elem()
+	result := reflect.MakeSlice(sliceType, a.Length(), a.Length())
+	for i := 0; i < a.Length(); i++ {
+		el := a.At(i)
+		if el == nil {
+			continue
+		}
+		value := reflect.ValueOf(el)
+		if !value.Type().AssignableTo(elementType) {
+			return &ElementTypeError{Index: i, Value: el, Type: elementType}
+		}
+		result.Index(i).Set(value)
+	}
+	pointer.Elem().Set(result)
+	return nil
 }
 
-// Swap swaps 2 elements
-func (s *sorter) Swap(i, j int) {
-	a := s.array.Splice(i, 1, s.array.At(j))
-	s.array.Splice(j, 1, a.At(0))
+// SliceOf returns the elements of the array as a []T.
+// nil elements become the zero value of T.
+// It returns an *ElementTypeError for the first element that is not a T.
+func SliceOf[T any](a ArrayInterface) ([]T, error) {
+	result := make([]T, a.Length())
+	for i := range result {
+		el := a.At(i)
+		if el == nil {
+			continue
+		}
+		value, ok := el.(T)
+		if !ok {
+			return nil, &ElementTypeError{Index: i, Value: el, Type: reflect.TypeOf((*T)(nil)).Elem()}
+		}
+		result[i] = value
+	}
+	return result, nil
 }
@@ -6,13 +6,25 @@ package array
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
 func expect(t *testing.T, actual interface{}, expected interface{}) {
+	t.Helper()
+	if !DeepEqual(actual, expected) {
+		t.Error(actual, "should be", expected)
+	}
+}
 
+// expectSame checks that actual is expected itself, such as a method returning its receiver
+func expectSame(t *testing.T, actual interface{}, expected interface{}) {
+	t.Helper()
 	if actual != expected {
-		t.Error(actual, "should be", expected)
+		t.Errorf("%p should be the same as %p", actual, expected)
 	}
 }
 
@@ -85,11 +97,15 @@ func TestNewArrayFrom(t *testing.T) {
 	t.Log(fixtures.At(4).(*fixture).args[1])
 	fixtures.ForEach(func(val interface{}, i int) {
 		var a ArrayInterface
+		var err error
 		fix := val.(*fixture)
 		if len(fix.args) == 2 {
-			a = NewFrom(fix.args[1])
+			a, err = NewFrom(fix.args[1])
 		} else {
-			a = NewFrom(fix.args[1], fix.args[2].(func(interface{}, ArrayInterface) error))
+			a, err = NewFrom(fix.args[1], fix.args[2].(func(interface{}, ArrayInterface) error))
+		}
+		if err != nil {
+			t.Fatal(err)
 		}
 		val.(*fixture).args[0].(*Array).ForEach(func(el interface{}, i int) {
 
@@ -98,6 +114,118 @@ func TestNewArrayFrom(t *testing.T) {
 	})
 }
 
+type weekday int
+
+func TestNewFromReflection(t *testing.T) {
+	type order struct{ id int }
+	first, second := &order{1}, &order{2}
+	ch := make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+	seq := func(yield func(int) bool) {
+		for i := 0; i < 3; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	type fixture struct {
+		collection interface{}
+		expected   []interface{}
+	}
+	for _, fix := range []fixture{
+		{[]*order{first, second}, []interface{}{first, second}},
+		{[]weekday{1, 2}, []interface{}{weekday(1), weekday(2)}},
+		{[2]int{3, 4}, []interface{}{3, 4}},
+		{New(1, 2), []interface{}{1, 2}},
+		{"ab", []interface{}{'a', 'b'}},
+		{ch, []interface{}{"a", "b"}},
+		{seq, []interface{}{0, 1, 2}},
+	} {
+		a, err := NewFrom(fix.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, a.Length(), len(fix.expected))
+		for i, v := range fix.expected {
+			expect(t, a.At(i), v)
+		}
+	}
+
+	a, err := NewFrom(map[string]int{"a": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, a.Length(), 1)
+	expect(t, a.At(0).(ArrayInterface).At(0), "a")
+	expect(t, a.At(0).(ArrayInterface).At(1), 1)
+
+	source := New(1)
+	a, _ = NewFrom(source)
+	a.Push(2)
+	expect(t, source.Length(), 1)
+}
+
+func TestNewFromUnsupported(t *testing.T) {
+	for _, collection := range []interface{}{nil, 1, struct{}{}, make(chan<- int), (*Array)(nil), (*SyncArray)(nil)} {
+		if _, err := NewFrom(collection); !errors.Is(err, ErrUnsupportedType) {
+			t.Error(err, "should be", ErrUnsupportedType)
+		}
+	}
+	delegateErr := errors.New("nope")
+	_, err := NewFrom(1, func(interface{}, ArrayInterface) error { return delegateErr })
+	if !errors.Is(err, delegateErr) {
+		t.Error(err, "should wrap", delegateErr)
+	}
+}
+
+func TestToSlice(t *testing.T) {
+	var ints []int
+	if err := ToSlice(New(1, 2, nil), &ints); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, len(ints), 3)
+	expect(t, ints[1], 2)
+	expect(t, ints[2], 0)
+
+	var stringers []fmt.Stringer
+	if err := ToSlice(New(New(1)), &stringers); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, stringers[0].String(), "ArrayInterface[1]")
+
+	err := ToSlice(New(1, "two", 3), &ints)
+	var typeError *ElementTypeError
+	if !errors.As(err, &typeError) {
+		t.Fatal(err, "should be an *ElementTypeError")
+	}
+	expect(t, typeError.Index, 1)
+	expect(t, errors.Is(err, ErrTypeMismatch), true)
+	expect(t, len(ints), 3)
+
+	if err := ToSlice(New(1), ints); !errors.Is(err, ErrUnsupportedType) {
+		t.Error(err, "should be", ErrUnsupportedType)
+	}
+}
+
+func TestSliceOf(t *testing.T) {
+	strs, err := SliceOf[string](New("a", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, len(strs), 2)
+	expect(t, strs[1], "b")
+
+	_, err = SliceOf[string](New("a", 2))
+	var typeError *ElementTypeError
+	if !errors.As(err, &typeError) {
+		t.Fatal(err, "should be an *ElementTypeError")
+	}
+	expect(t, typeError.Index, 1)
+	expect(t, typeError.Value, 2)
+}
+
 func TestPush(t *testing.T) {
 	a := New()
 	a.Push("foo", "bar")
@@ -183,6 +311,64 @@ func TestShift(t *testing.T) {
 	}
 }
 
+func TestShiftEmpty(t *testing.T) {
+	a := New()
+	expect(t, a.Shift(), nil)
+	expect(t, a.Length(), 0)
+}
+
+func TestTryAtPopShift(t *testing.T) {
+	a := New(nil, "foo")
+	value, ok := a.TryAt(0)
+	expect(t, value, nil)
+	expect(t, ok, true)
+	_, ok = a.TryAt(2)
+	expect(t, ok, false)
+	_, ok = a.TryAt(-3)
+	expect(t, ok, false)
+
+	value, ok = a.TryPop()
+	expect(t, value, "foo")
+	expect(t, ok, true)
+	value, ok = a.TryShift()
+	expect(t, value, nil)
+	expect(t, ok, true)
+	_, ok = a.TryPop()
+	expect(t, ok, false)
+	_, ok = a.TryShift()
+	expect(t, ok, false)
+}
+
+func TestErrorVariants(t *testing.T) {
+	a := New(1)
+	value, err := a.AtE(0)
+	expect(t, value, 1)
+	expect(t, err, nil)
+	_, err = a.AtE(5)
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Error(err, "should be", ErrIndexOutOfRange)
+	}
+	var indexError *IndexError
+	if errors.As(err, &indexError) {
+		expect(t, indexError.Index, 5)
+		expect(t, indexError.Length, 1)
+	} else {
+		t.Error(err, "should be an *IndexError")
+	}
+
+	value, err = a.PopE()
+	expect(t, value, 1)
+	expect(t, err, nil)
+	_, err = a.PopE()
+	expect(t, err, ErrEmpty)
+	_, err = a.ShiftE()
+	expect(t, err, ErrEmpty)
+	a.Push(2)
+	value, err = a.ShiftE()
+	expect(t, value, 2)
+	expect(t, err, nil)
+}
+
 func TestSplice(t *testing.T) {
 	type fixture struct {
 		array     ArrayInterface
@@ -337,22 +523,16 @@ func TestSort(t *testing.T) {
 		callback func(a, b interface{}) bool
 	}
 	fixtures := New(&fixture{
-		New(1, 2, 3),
+		New(3, 1, 2),
 		New(1, 2, 3),
 		func(a, b interface{}) bool {
-			if a.(int) <= b.(int) {
-				return true
-			}
-			return false
+			return a.(int) < b.(int)
 		},
 	}, &fixture{
 		New(1, 2, 3),
 		New(3, 2, 1),
 		func(a, b interface{}) bool {
-			if a.(int) <= b.(int) {
-				return false
-			}
-			return true
+			return a.(int) > b.(int)
 		},
 	})
 	fixtures.ForEach(func(v interface{}, i int) {
@@ -431,3 +611,328 @@ func TestLastIndexOf(t *testing.T) {
 		expect(t, i, fixture.expected)
 	})
 }
+
+func expectArray(t *testing.T, actual ArrayInterface, expected ...interface{}) {
+	t.Helper()
+	if actual.Length() != len(expected) {
+		t.Fatal(actual, "should have length", len(expected))
+	}
+	for i, v := range expected {
+		expect(t, actual.At(i), v)
+	}
+}
+
+func TestNegativeIndexes(t *testing.T) {
+	a := New(1, 2, 3, 1)
+	type fixture struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+	}
+	for _, fix := range []fixture{
+		{"At(-1)", a.At(-1), 1},
+		{"At(-4)", a.At(-4), 1},
+		{"At(-5)", a.At(-5), nil},
+		{"IndexOf(1, -1)", a.IndexOf(1, -1), 3},
+		{"IndexOf(1, -10)", a.IndexOf(1, -10), 0},
+		{"LastIndexOf(1, -2)", a.LastIndexOf(1, -2), 0},
+		{"LastIndexOf(1, 10)", a.LastIndexOf(1, 10), 3},
+		{"LastIndexOf(1, -5)", a.LastIndexOf(1, -5), -1},
+		{"LastIndexOf(nil, 10)", a.LastIndexOf(nil, 10), -1},
+	} {
+		if fix.actual != fix.expected {
+			t.Error(fix.name, fix.actual, "should be", fix.expected)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	isEven := func(v interface{}, i int) bool { return v.(int)%2 == 0 }
+	type fixture struct {
+		array         ArrayInterface
+		find          interface{}
+		findIndex     int
+		findLast      interface{}
+		findLastIndex int
+	}
+	for _, fix := range []fixture{
+		{New(1, 2, 3, 4, 5), 2, 1, 4, 3},
+		{New(1, 3), nil, -1, nil, -1},
+		{New(), nil, -1, nil, -1},
+	} {
+		value, ok := fix.array.Find(isEven)
+		expect(t, value, fix.find)
+		expect(t, ok, fix.findIndex >= 0)
+		expect(t, fix.array.FindIndex(isEven), fix.findIndex)
+		value, ok = fix.array.FindLast(isEven)
+		expect(t, value, fix.findLast)
+		expect(t, ok, fix.findLastIndex >= 0)
+		expect(t, fix.array.FindLastIndex(isEven), fix.findLastIndex)
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	type fixture struct {
+		array    ArrayInterface
+		value    interface{}
+		expected bool
+	}
+	for _, fix := range []fixture{
+		{New(1, 2, 3), 2, true},
+		{New(1, 2, 3), 4, false},
+		{New(1, nil), nil, true},
+		{New(math.NaN()), math.NaN(), true},
+		{New(float32(math.NaN())), math.NaN(), true},
+		{New(1.0), math.NaN(), false},
+	} {
+		expect(t, fix.array.Includes(fix.value), fix.expected)
+	}
+	expect(t, New(math.NaN()).IndexOf(math.NaN(), 0), -1)
+}
+
+func TestFill(t *testing.T) {
+	type fixture struct {
+		args     []int
+		expected []interface{}
+	}
+	for _, fix := range []fixture{
+		{nil, []interface{}{0, 0, 0}},
+		{[]int{1}, []interface{}{1, 0, 0}},
+		{[]int{1, 2}, []interface{}{1, 0, 3}},
+		{[]int{-2, -1}, []interface{}{1, 0, 3}},
+		{[]int{3, 3}, []interface{}{1, 2, 3}},
+		{[]int{2, 1}, []interface{}{1, 2, 3}},
+	} {
+		a := New(1, 2, 3)
+		expectSame(t, a.Fill(0, fix.args...), a)
+		expectArray(t, a, fix.expected...)
+	}
+}
+
+func TestCopyWithin(t *testing.T) {
+	type fixture struct {
+		target   int
+		args     []int
+		expected []interface{}
+	}
+	for _, fix := range []fixture{
+		{-2, nil, []interface{}{1, 2, 3, 1, 2}},
+		{0, []int{3}, []interface{}{4, 5, 3, 4, 5}},
+		{0, []int{3, 4}, []interface{}{4, 2, 3, 4, 5}},
+		{-2, []int{-3, -1}, []interface{}{1, 2, 3, 3, 4}},
+		{1, []int{0}, []interface{}{1, 1, 2, 3, 4}},
+	} {
+		a := New(1, 2, 3, 4, 5)
+		expectSame(t, a.CopyWithin(fix.target, fix.args...), a)
+		expectArray(t, a, fix.expected...)
+	}
+}
+
+func TestFlat(t *testing.T) {
+	a := New(1, New(2, New(3, New(4))), 5)
+	type fixture struct {
+		depth    int
+		expected []interface{}
+	}
+	for _, fix := range []fixture{
+		{0, []interface{}{1, a.At(1), 5}},
+		{1, []interface{}{1, 2, a.At(1).(ArrayInterface).At(1), 5}},
+		{3, []interface{}{1, 2, 3, 4, 5}},
+		{10, []interface{}{1, 2, 3, 4, 5}},
+	} {
+		expectArray(t, a.Flat(fix.depth), fix.expected...)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	a := New(1, 2, 3)
+	result := a.FlatMap(func(v interface{}, i int) interface{} {
+		if v.(int) == 2 {
+			return New()
+		}
+		if v.(int) == 3 {
+			return New(3, New(3))
+		}
+		return v
+	})
+	expect(t, result.Length(), 3)
+	expect(t, result.At(0), 1)
+	expect(t, result.At(1), 3)
+	expect(t, result.At(2).(ArrayInterface).At(0), 3)
+}
+
+func TestJoin(t *testing.T) {
+	type fixture struct {
+		array     ArrayInterface
+		separator string
+		expected  string
+	}
+	for _, fix := range []fixture{
+		{New(1, "a", 2.5), "-", "1-a-2.5"},
+		{New(), ",", ""},
+		{New(nil, 1, nil), ",", ",1,"},
+		{New(1, New(2, New(3))), " ", "1 2,3"},
+	} {
+		expect(t, fix.array.Join(fix.separator), fix.expected)
+	}
+}
+
+func TestKeysEntries(t *testing.T) {
+	a := New("a", "b")
+	expectArray(t, a.Keys(), 0, 1)
+	entries := a.Entries()
+	expect(t, entries.Length(), 2)
+	expectArray(t, entries.At(1).(ArrayInterface), 1, "b")
+}
+
+func TestCopyVariants(t *testing.T) {
+	type fixture struct {
+		name     string
+		copy     func(a ArrayInterface) ArrayInterface
+		expected []interface{}
+	}
+	for _, fix := range []fixture{
+		{"ToReversed", func(a ArrayInterface) ArrayInterface { return a.ToReversed() }, []interface{}{2, 1, 3}},
+		{"ToSorted", func(a ArrayInterface) ArrayInterface { return a.ToSorted(CompareNumbers) }, []interface{}{1, 2, 3}},
+		{"ToSpliced", func(a ArrayInterface) ArrayInterface { return a.ToSpliced(1, 1, 4, 5) }, []interface{}{3, 4, 5, 2}},
+		{"ToSpliced negative start", func(a ArrayInterface) ArrayInterface { return a.ToSpliced(-1, 1) }, []interface{}{3, 1}},
+		{"With", func(a ArrayInterface) ArrayInterface {
+			result, err := a.With(-1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return result
+		}, []interface{}{3, 1, 0}},
+	} {
+		a := New(3, 1, 2)
+		result := fix.copy(a)
+		if result == a {
+			t.Error(fix.name, "should return a new array")
+		}
+		expectArray(t, result, fix.expected...)
+		expectArray(t, a, 3, 1, 2)
+	}
+}
+
+func TestWithOutOfRange(t *testing.T) {
+	for _, index := range []int{3, -4} {
+		if _, err := New(1, 2, 3).With(index, 0); !errors.Is(err, ErrIndexOutOfRange) {
+			t.Error(err, "should be", ErrIndexOutOfRange)
+		}
+	}
+}
+
+func TestReverseInPlace(t *testing.T) {
+	for _, values := range [][]interface{}{{}, {1}, {1, 2}, {1, 2, 3}} {
+		a := New(values...)
+		// wrap values around the ring buffer
+		a.Unshift(0)
+		expectSame(t, a.ReverseInPlace(), a)
+		for i := range values {
+			expect(t, a.At(i), values[len(values)-1-i])
+		}
+		expect(t, a.At(-1), 0)
+	}
+}
+
+func TestSpliceCases(t *testing.T) {
+	type fixture struct {
+		start, deleteCount int
+		items              []interface{}
+		removed, expected  []interface{}
+	}
+	for _, fix := range []fixture{
+		{1, 0, []interface{}{"a"}, []interface{}{}, []interface{}{1, "a", 2, 3, 4}},
+		{1, 2, nil, []interface{}{2, 3}, []interface{}{1, 4}},
+		{1, 2, []interface{}{"a", "b", "c"}, []interface{}{2, 3}, []interface{}{1, "a", "b", "c", 4}},
+		{-1, 5, nil, []interface{}{4}, []interface{}{1, 2, 3}},
+		{10, 1, []interface{}{5}, []interface{}{}, []interface{}{1, 2, 3, 4, 5}},
+		{0, -1, []interface{}{0}, []interface{}{}, []interface{}{0, 1, 2, 3, 4}},
+		{0, 4, nil, []interface{}{1, 2, 3, 4}, []interface{}{}},
+	} {
+		a := New(2, 3, 4)
+		// wrap values around the ring buffer
+		a.Unshift(1)
+		removed := a.Splice(fix.start, fix.deleteCount, fix.items...)
+		expectArray(t, removed, fix.removed...)
+		expectArray(t, a, fix.expected...)
+	}
+}
+
+func TestSpliceAgainstSlices(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	a := New()
+	var model []interface{}
+	for i := 0; i < 2000; i++ {
+		start := random.Intn(len(model) + 1)
+		deleteCount := random.Intn(len(model) - start + 1)
+		items := make([]interface{}, random.Intn(4))
+		for j := range items {
+			items[j] = i*10 + j
+		}
+		if random.Intn(3) == 0 {
+			a.Unshift(i)
+			model = append([]interface{}{i}, model...)
+			start++
+		}
+		a.Splice(start, deleteCount, items...)
+		model = slices.Insert(slices.Delete(model, start, start+deleteCount), start, items...)
+		if !a.Equal(New(model...)) {
+			t.Fatal(a, "should be", model)
+		}
+	}
+}
+
+func TestConcatImplementations(t *testing.T) {
+	wrapped := New(2)
+	wrapped.Unshift(1)
+	result := New(0).Concat(wrapped, NewSync(3), New(), New(4))
+	expectArray(t, result, 0, 1, 2, 3, 4)
+	result.Push(5)
+	expect(t, wrapped.Length(), 2)
+}
+
+func BenchmarkSplice(b *testing.B) {
+	const size = 10000
+	values := make([]interface{}, size)
+	for i := range values {
+		values[i] = i
+	}
+	b.Run("Array", func(b *testing.B) {
+		a := New(values...)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			a.Splice(size/2, 1, i, i)
+			a.Splice(size/3, 1)
+		}
+	})
+	b.Run("slice", func(b *testing.B) {
+		s := slices.Clone(values)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s = slices.Insert(slices.Delete(s, size/2, size/2+1), size/2, interface{}(i), interface{}(i))
+			s = slices.Delete(s, size/3, size/3+1)
+		}
+	})
+}
+
+func BenchmarkConcat(b *testing.B) {
+	values := make([]interface{}, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	a, other := New(values...), New(values...)
+	var length int
+	b.Run("Array", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			length += a.Concat(other, other).Length()
+		}
+	})
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			length += len(slices.Concat(values, values, values))
+		}
+	})
+}
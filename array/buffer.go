@@ -0,0 +1,173 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+// minCapacity is the smallest buffer allocated by an Array
+const minCapacity = 8
+
+// index maps an array index to a position in the ring buffer
+func (a *Array) index(i int) int {
+	i += a.head
+	if i >= len(a.buffer) {
+		i -= len(a.buffer)
+	}
+	return i
+}
+
+// get returns the value at array index i, i must be in range
+func (a *Array) get(i int) interface{} {
+	return a.buffer[a.index(i)]
+}
+
+// set replaces the value at array index i, i must be in range
+func (a *Array) set(i int, value interface{}) {
+	a.buffer[a.index(i)] = value
+}
+
+// copyTo copies values in [begin, end) into dst and returns the number of copied values
+func (a *Array) copyTo(dst []interface{}, begin, end int) int {
+	n := 0
+	for begin < end && n < len(dst) {
+		start := a.index(begin)
+		stop := start + end - begin
+		if stop > len(a.buffer) {
+			stop = len(a.buffer)
+		}
+		copied := copy(dst[n:], a.buffer[start:stop])
+		n += copied
+		begin += copied
+	}
+	return n
+}
+
+// slice returns a new Array holding a copy of values in [begin, end)
+func (a *Array) slice(begin, end int) *Array {
+	values := make([]interface{}, end-begin)
+	a.copyTo(values, begin, end)
+	return &Array{buffer: values, length: len(values)}
+}
+
+// resize moves values into a new buffer of the given capacity, starting at position 0
+func (a *Array) resize(capacity int) {
+	buffer := make([]interface{}, capacity)
+	a.copyTo(buffer, 0, a.length)
+	a.buffer = buffer
+	a.head = 0
+}
+
+// grow makes room for n more values, doubling the buffer capacity when needed
+func (a *Array) grow(n int) {
+	if a.length+n <= len(a.buffer) {
+		return
+	}
+	capacity := len(a.buffer) * 2
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	for capacity < a.length+n {
+		capacity *= 2
+	}
+	a.resize(capacity)
+}
+
+// shrink halves the buffer when it is mostly empty so memory is reclaimed
+func (a *Array) shrink() {
+	if len(a.buffer) > minCapacity && a.length <= len(a.buffer)/4 && len(a.buffer)/2 >= a.reserved {
+		a.resize(len(a.buffer) / 2)
+	}
+}
+
+// contiguous rearranges the buffer so values don't wrap around
+// and returns them as a slice sharing the buffer
+func (a *Array) contiguous() []interface{} {
+	if a.head+a.length > len(a.buffer) {
+		a.resize(len(a.buffer))
+	}
+	return a.buffer[a.head : a.head+a.length]
+}
+
+// Cap returns the number of elements the array can hold without allocating
+func (a *Array) Cap() int {
+	return len(a.buffer)
+}
+
+// Grow makes room for n more elements, so that pushing them doesn't allocate
+func (a *Array) Grow(n int) {
+	if n > 0 && a.length+n > len(a.buffer) {
+		a.resize(a.length + n)
+	}
+}
+
+// Clip releases the unused capacity of the array
+func (a *Array) Clip() {
+	a.reserved = 0
+	if a.length == 0 {
+		a.buffer, a.head = nil, 0
+		return
+	}
+	if a.length < len(a.buffer) {
+		a.resize(a.length)
+	}
+}
+
+// Clear removes all the elements, keeping the capacity of the array.
+// References to the elements are released so they can be garbage collected
+func (a *Array) Clear() {
+	a.Truncate(0)
+	a.head = 0
+}
+
+// Truncate removes the elements from index n, keeping the capacity of the array.
+// References to the elements are released so they can be garbage collected
+func (a *Array) Truncate(n int) {
+	if n < 0 {
+		n = 0
+	}
+	for i := n; i < a.length; i++ {
+		a.set(i, nil)
+	}
+	if n < a.length {
+		a.length = n
+	}
+}
+
+// insert inserts value at array index i, moving the values on the shorter side of i
+func (a *Array) insert(i int, value interface{}) {
+	a.grow(1)
+	if i < a.length/2 {
+		a.head = a.index(len(a.buffer) - 1)
+		a.length++
+		for j := 0; j < i; j++ {
+			a.set(j, a.get(j+1))
+		}
+	} else {
+		a.length++
+		for j := a.length - 1; j > i; j-- {
+			a.set(j, a.get(j-1))
+		}
+	}
+	a.set(i, value)
+}
+
+// remove removes the value at array index i and returns it,
+// moving the values on the shorter side of i
+func (a *Array) remove(i int) interface{} {
+	value := a.get(i)
+	if i < a.length/2 {
+		for j := i; j > 0; j-- {
+			a.set(j, a.get(j-1))
+		}
+		a.set(0, nil)
+		a.head = a.index(1)
+	} else {
+		for j := i; j < a.length-1; j++ {
+			a.set(j, a.get(j+1))
+		}
+		a.set(a.length-1, nil)
+	}
+	a.length--
+	a.shrink()
+	return value
+}
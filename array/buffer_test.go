@@ -0,0 +1,198 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// TestRingBuffer compares an Array against a plain slice on random operations
+// at both ends so that wrapping around the buffer is exercised
+func TestRingBuffer(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	a := New().(*Array)
+	var model []interface{}
+	for i := 0; i < 10000; i++ {
+		switch random.Intn(4) {
+		case 0:
+			a.Push(i, i+1)
+			model = append(model, i, i+1)
+		case 1:
+			a.Unshift(i, i+1)
+			model = append([]interface{}{i + 1, i}, model...)
+		case 2:
+			var expected interface{}
+			if len(model) > 0 {
+				expected, model = model[len(model)-1], model[:len(model)-1]
+			}
+			expect(t, a.Pop(), expected)
+		case 3:
+			var expected interface{}
+			if len(model) > 0 {
+				expected, model = model[0], model[1:]
+			}
+			expect(t, a.Shift(), expected)
+		}
+		if a.Length() != len(model) {
+			t.Fatal(a.Length(), "should be", len(model))
+		}
+	}
+	for i, v := range model {
+		expect(t, a.At(i), v)
+	}
+	values := a.ArrayInterface()
+	for i, v := range model {
+		expect(t, values[i], v)
+	}
+}
+
+func TestRingBufferReclaimsMemory(t *testing.T) {
+	a := New().(*Array)
+	for i := 0; i < 10000; i++ {
+		a.Push(i)
+	}
+	for i := 0; i < 10000; i++ {
+		a.Shift()
+	}
+	if len(a.buffer) > minCapacity {
+		t.Error("buffer capacity", len(a.buffer), "should be reclaimed")
+	}
+	a.Push(1)
+	a.Shift()
+	for _, v := range a.buffer {
+		expect(t, v, nil)
+	}
+}
+
+// BenchmarkShift shows Shift costs the same regardless of the array length
+func BenchmarkShift(b *testing.B) {
+	for _, size := range []int{1000, 100000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			a := New()
+			for i := 0; i < size; i++ {
+				a.Push(i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				a.Push(a.Shift())
+			}
+		})
+	}
+}
+
+// BenchmarkUnshift shows Unshift costs the same regardless of the array length
+func BenchmarkUnshift(b *testing.B) {
+	for _, size := range []int{1000, 100000} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			a := New()
+			for i := 0; i < size; i++ {
+				a.Push(i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				a.Unshift(a.Pop())
+			}
+		})
+	}
+}
+
+// BenchmarkQueue pushes at the tail and shifts at the head
+func BenchmarkQueue(b *testing.B) {
+	b.ReportAllocs()
+	a := New()
+	for i := 0; i < b.N; i++ {
+		a.Push(i)
+		if a.Length() > 1024 {
+			a.Shift()
+		}
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	a := NewWithCapacity(100)
+	expect(t, a.Cap(), 100)
+	expect(t, a.Length(), 0)
+	a.Push(1, 2, 3)
+	a.Pop()
+	a.Pop()
+	expect(t, a.Cap(), 100)
+	a.Clip()
+	expect(t, a.Cap(), 1)
+	expectArray(t, a, 1)
+
+	a.Grow(10)
+	expect(t, a.Cap(), 11)
+	a.Grow(5)
+	expect(t, a.Cap(), 11)
+	a.Unshift(0)
+	a.Clip()
+	expect(t, a.Cap(), 2)
+	expectArray(t, a, 0, 1)
+}
+
+func TestClearTruncate(t *testing.T) {
+	a := New(1, 2, 3, 4).(*Array)
+	// wrap values around the ring buffer
+	a.Unshift(0)
+	capacity := a.Cap()
+	a.Truncate(10)
+	expectArray(t, a, 0, 1, 2, 3, 4)
+	a.Truncate(2)
+	expectArray(t, a, 0, 1)
+	expect(t, a.Cap(), capacity)
+	live := 0
+	for _, v := range a.buffer {
+		if v != nil {
+			live++
+		}
+	}
+	expect(t, live, 2)
+	a.Clear()
+	expect(t, a.Length(), 0)
+	expect(t, a.Cap(), capacity)
+	for _, v := range a.buffer {
+		expect(t, v, nil)
+	}
+	a.Push(5)
+	expectArray(t, a, 5)
+	a.Clip()
+	a.Clear()
+	a.Clip()
+	expect(t, a.Cap(), 0)
+}
+
+func BenchmarkBuild(b *testing.B) {
+	const size = 10000
+	b.Run("Push", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			a := New()
+			for j := 0; j < size; j++ {
+				a.Push(j)
+			}
+		}
+	})
+	b.Run("NewWithCapacity", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			a := NewWithCapacity(size)
+			for j := 0; j < size; j++ {
+				a.Push(j)
+			}
+		}
+	})
+	b.Run("Grow", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			a := New()
+			a.Grow(size)
+			for j := 0; j < size; j++ {
+				a.Push(j)
+			}
+		}
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"context"
+)
+
+// ForEachE executes callback on each element of the array and stops at the first error,
+// returned as an *ElementError holding the failing index
+func ForEachE(a ArrayInterface, callback func(value interface{}, i int) error) error {
+	return ForEachCtx(context.Background(), a, func(_ context.Context, value interface{}, i int) error {
+		return callback(value, i)
+	})
+}
+
+// MapE is like ArrayInterface.Map with a callback that can fail, see ForEachE
+func MapE(a ArrayInterface, callback func(value interface{}, i int) (interface{}, error)) (ArrayInterface, error) {
+	return MapCtx(context.Background(), a, func(_ context.Context, value interface{}, i int) (interface{}, error) {
+		return callback(value, i)
+	})
+}
+
+// FilterE is like ArrayInterface.Filter with a predicate that can fail, see ForEachE
+func FilterE(a ArrayInterface, predicate func(value interface{}, i int) (bool, error)) (ArrayInterface, error) {
+	return FilterCtx(context.Background(), a, func(_ context.Context, value interface{}, i int) (bool, error) {
+		return predicate(value, i)
+	})
+}
+
+// ReduceE is like ArrayInterface.Reduce with a callback that can fail, see ForEachE
+func ReduceE(a ArrayInterface, callback func(result interface{}, value interface{}, i int) (interface{}, error), initial interface{}) (interface{}, error) {
+	return ReduceCtx(context.Background(), a, func(_ context.Context, result interface{}, value interface{}, i int) (interface{}, error) {
+		return callback(result, value, i)
+	}, initial)
+}
+
+// ForEachCtx is like ForEachE, it also stops with ctx.Err() when ctx is done
+// before an element is processed
+func ForEachCtx(ctx context.Context, a ArrayInterface, callback func(ctx context.Context, value interface{}, i int) error) error {
+	for i, value := range a.All() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := callback(ctx, value, i); err != nil {
+			return &ElementError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// MapCtx is like MapE, it also stops with ctx.Err() when ctx is done
+func MapCtx(ctx context.Context, a ArrayInterface, callback func(ctx context.Context, value interface{}, i int) (interface{}, error)) (ArrayInterface, error) {
+	result := &Array{}
+	err := ForEachCtx(ctx, a, func(ctx context.Context, value interface{}, i int) error {
+		mapped, err := callback(ctx, value, i)
+		result.Push(mapped)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FilterCtx is like FilterE, it also stops with ctx.Err() when ctx is done
+func FilterCtx(ctx context.Context, a ArrayInterface, predicate func(ctx context.Context, value interface{}, i int) (bool, error)) (ArrayInterface, error) {
+	result := &Array{}
+	err := ForEachCtx(ctx, a, func(ctx context.Context, value interface{}, i int) error {
+		keep, err := predicate(ctx, value, i)
+		if keep && err == nil {
+			result.Push(value)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReduceCtx is like ReduceE, it also stops with ctx.Err() when ctx is done
+func ReduceCtx(ctx context.Context, a ArrayInterface, callback func(ctx context.Context, result interface{}, value interface{}, i int) (interface{}, error), initial interface{}) (interface{}, error) {
+	err := ForEachCtx(ctx, a, func(ctx context.Context, value interface{}, i int) (err error) {
+		initial, err = callback(ctx, initial, value, i)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return initial, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var errNegative = errors.New("negative value")
+
+func checkPositive(value interface{}, i int) error {
+	if value.(int) < 0 {
+		return errNegative
+	}
+	return nil
+}
+
+func expectElementError(t *testing.T, err error, index int, cause error) {
+	t.Helper()
+	var elementError *ElementError
+	if !errors.As(err, &elementError) {
+		t.Fatal(err, "should be an *ElementError")
+	}
+	expect(t, elementError.Index, index)
+	if !errors.Is(err, cause) {
+		t.Error(err, "should be", cause)
+	}
+}
+
+func TestForEachE(t *testing.T) {
+	visited := 0
+	err := ForEachE(New(1, 2, -3, 4), func(value interface{}, i int) error {
+		visited++
+		return checkPositive(value, i)
+	})
+	expectElementError(t, err, 2, errNegative)
+	expect(t, visited, 3)
+	expect(t, ForEachE(New(1, 2), checkPositive), nil)
+}
+
+func TestMapFilterReduceE(t *testing.T) {
+	double := func(value interface{}, i int) (interface{}, error) {
+		return value.(int) * 2, checkPositive(value, i)
+	}
+	mapped, err := MapE(New(1, 2), double)
+	expect(t, err, nil)
+	expectArray(t, mapped, 2, 4)
+	_, err = MapE(New(1, -2), double)
+	expectElementError(t, err, 1, errNegative)
+
+	odd := func(value interface{}, i int) (bool, error) {
+		return value.(int)%2 != 0, checkPositive(value, i)
+	}
+	filtered, err := FilterE(New(1, 2, 3), odd)
+	expect(t, err, nil)
+	expectArray(t, filtered, 1, 3)
+	_, err = FilterE(New(-1), odd)
+	expectElementError(t, err, 0, errNegative)
+
+	sum := func(result interface{}, value interface{}, i int) (interface{}, error) {
+		return result.(int) + value.(int), checkPositive(value, i)
+	}
+	total, err := ReduceE(New(1, 2, 3), sum, 0)
+	expect(t, err, nil)
+	expect(t, total, 6)
+	_, err = ReduceE(New(1, 2, -3), sum, 0)
+	expectElementError(t, err, 2, errNegative)
+}
+
+func TestCtxVariantsStopOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	visited := 0
+	err := ForEachCtx(ctx, New(1, 2, 3), func(ctx context.Context, value interface{}, i int) error {
+		visited++
+		if i == 1 {
+			cancel()
+		}
+		return nil
+	})
+	expect(t, err, context.Canceled)
+	expect(t, visited, 2)
+
+	_, err = MapCtx(ctx, New(1), func(ctx context.Context, value interface{}, i int) (interface{}, error) {
+		t.Error("callback should not be called on a canceled context")
+		return value, nil
+	})
+	expect(t, err, context.Canceled)
+	_, err = FilterCtx(ctx, New(1), func(ctx context.Context, value interface{}, i int) (bool, error) {
+		return true, nil
+	})
+	expect(t, err, context.Canceled)
+	_, err = ReduceCtx(ctx, New(1), func(ctx context.Context, result, value interface{}, i int) (interface{}, error) {
+		return result, nil
+	}, 0)
+	expect(t, err, context.Canceled)
+}
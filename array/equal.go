@@ -0,0 +1,274 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// EqualityFunc returns true if a and b are equal
+type EqualityFunc func(a, b interface{}) bool
+
+// StrictEqual compares values with ==, like IndexOf does.
+// Unlike ==, it doesn't panic on uncomparable values such as slices and maps,
+// which are never strictly equal
+func StrictEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.ValueOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// DeepEqual compares values structurally like reflect.DeepEqual, except that
+// ArrayInterface values, including those nested in slices, maps, structs or pointers,
+// are compared element by element with Equal, whatever their internal layout
+func DeepEqual(a, b interface{}) bool {
+	return deepEqual(reflect.ValueOf(a), reflect.ValueOf(b), map[visit]bool{})
+}
+
+// visit is a pair of references already being compared by deepEqual,
+// which stops cycles from recursing forever
+type visit struct {
+	x, y uintptr
+	// length tells apart slices sharing the same backing array
+	length int
+	t      reflect.Type
+}
+
+// asArray returns the array held by v, if any. Pointers read from unexported
+// struct fields are rebuilt with reflect.NewAt so their methods can be called
+func asArray(v reflect.Value) (ArrayInterface, bool) {
+	if v.Kind() == reflect.Interface && !v.IsNil() && !v.CanInterface() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	if !v.CanInterface() {
+		if v.Kind() != reflect.Pointer {
+			return nil, false
+		}
+		v = reflect.NewAt(v.Type().Elem(), v.UnsafePointer())
+	}
+	a, ok := v.Interface().(ArrayInterface)
+	return a, ok
+}
+
+// deepEqual compares x and y recursively, comparing nested arrays with equal
+func deepEqual(x, y reflect.Value, visited map[visit]bool) bool {
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+	if a, ok := asArray(x); ok {
+		if b, ok := asArray(y); ok {
+			return equal(a, b)
+		}
+	}
+	if x.Type() != y.Type() {
+		return false
+	}
+	switch x.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() == y.IsNil()
+		}
+		if x.Kind() != reflect.Slice && x.Pointer() == y.Pointer() {
+			return true
+		}
+		v := visit{x.Pointer(), y.Pointer(), 0, x.Type()}
+		if x.Kind() == reflect.Slice {
+			v.length = x.Len()
+		}
+		if visited[v] {
+			return true
+		}
+		visited[v] = true
+	}
+	switch x.Kind() {
+	case reflect.Interface:
+		if x.IsNil() || y.IsNil() {
+			return x.IsNil() == y.IsNil()
+		}
+		return deepEqual(x.Elem(), y.Elem(), visited)
+	case reflect.Pointer:
+		return deepEqual(x.Elem(), y.Elem(), visited)
+	case reflect.Slice, reflect.Array:
+		if x.Len() != y.Len() {
+			return false
+		}
+		for i := 0; i < x.Len(); i++ {
+			if !deepEqual(x.Index(i), y.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if x.Len() != y.Len() {
+			return false
+		}
+		for iter := x.MapRange(); iter.Next(); {
+			value := y.MapIndex(iter.Key())
+			if !value.IsValid() || !deepEqual(iter.Value(), value, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			if !deepEqual(x.Field(i), y.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Bool:
+		return x.Bool() == y.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.Int() == y.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.Uint() == y.Uint()
+	case reflect.Float32, reflect.Float64:
+		return x.Float() == y.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return x.Complex() == y.Complex()
+	case reflect.String:
+		return x.String() == y.String()
+	case reflect.Func:
+		// like reflect.DeepEqual, functions are only equal if both are nil
+		return x.IsNil() && y.IsNil()
+	}
+	// channels and unsafe pointers
+	return x.Pointer() == y.Pointer()
+}
+
+// Equal returns true if other has the same length and deeply equal elements
+func (a *Array) Equal(other ArrayInterface) bool {
+	return equal(a, other)
+}
+
+// equal compares 2 arrays element by element with DeepEqual
+func equal(a, b ArrayInterface) bool {
+	if a.Length() != b.Length() {
+		return false
+	}
+	for i := 0; i < a.Length(); i++ {
+		if !DeepEqual(a.At(i), b.At(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexOfFunc is like IndexOf but compares elements with equal
+func (a *Array) IndexOfFunc(searchElement interface{}, fromIndex int, equal EqualityFunc) int {
+	for i := relativeIndex(fromIndex, a.length); i < a.length; i++ {
+		if equal(a.get(i), searchElement) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IncludesFunc is like Includes but compares elements with equal
+func (a *Array) IncludesFunc(searchElement interface{}, equal EqualityFunc) bool {
+	return a.IndexOfFunc(searchElement, 0, equal) >= 0
+}
+
+// Compare compares arrays lexicographically, returning -1, 0 or 1.
+// Elements are compared with compare, or CompareNatural if compare is nil.
+// Nested ArrayInterface values are compared recursively
+func (a *Array) Compare(other ArrayInterface, compare Comparator) int {
+	if compare == nil {
+		compare = CompareNatural
+	}
+	return compareArrays(a, other, compare)
+}
+
+// compareArrays compares 2 arrays lexicographically
+func compareArrays(a, b ArrayInterface, compare Comparator) int {
+	for i := 0; i < a.Length() && i < b.Length(); i++ {
+		x, y := a.At(i), b.At(i)
+		var result int
+		if nestedX, ok := x.(ArrayInterface); ok {
+			if nestedY, ok := y.(ArrayInterface); ok {
+				result = compareArrays(nestedX, nestedY, compare)
+			} else {
+				result = compare(x, y)
+			}
+		} else {
+			result = compare(x, y)
+		}
+		if result != 0 {
+			return cmp.Compare(result, 0)
+		}
+	}
+	return cmp.Compare(a.Length(), b.Length())
+}
+
+// naturalRank orders values of different kinds in CompareNatural
+func naturalRank(value interface{}) int {
+	if value == nil {
+		return 0
+	}
+	if _, ok := value.(ArrayInterface); ok {
+		return 4
+	}
+	if _, ok := toNumber(value); ok {
+		return 2
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool:
+		return 1
+	case reflect.String:
+		return 3
+	}
+	return -1
+}
+
+// CompareNatural compares nil, booleans, numbers, strings and arrays.
+// Values of different kinds are ordered nil < bool < number < string < array,
+// arrays are compared lexicographically.
+//
+// CAN PANIC if a value is of another kind
+func CompareNatural(a, b interface{}) int {
+	x, y := naturalRank(a), naturalRank(b)
+	if x < 0 {
+		panic(fmt.Sprintf("array: can't compare %+v", a))
+	}
+	if y < 0 {
+		panic(fmt.Sprintf("array: can't compare %+v", b))
+	}
+	if x != y {
+		return cmp.Compare(x, y)
+	}
+	switch x {
+	case 1:
+		p, q := reflect.ValueOf(a).Bool(), reflect.ValueOf(b).Bool()
+		switch {
+		case p == q:
+			return 0
+		case q:
+			return -1
+		}
+		return 1
+	case 2:
+		return CompareNumbers(a, b)
+	case 3:
+		return strings.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+	case 4:
+		return compareArrays(a.(ArrayInterface), b.(ArrayInterface), CompareNatural)
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStrictEqual(t *testing.T) {
+	slice := []int{1}
+	type fixture struct {
+		a, b     interface{}
+		expected bool
+	}
+	for _, fix := range []fixture{
+		{1, 1, true},
+		{1, int64(1), false},
+		{nil, nil, true},
+		{nil, 1, false},
+		{slice, slice, false},
+		{map[string]int{}, map[string]int{}, false},
+		{struct{ v interface{} }{slice}, struct{ v interface{} }{slice}, false},
+	} {
+		if StrictEqual(fix.a, fix.b) != fix.expected {
+			t.Error(fix.a, fix.b, "strict equality should be", fix.expected)
+		}
+	}
+}
+
+func TestIndexOfUncomparable(t *testing.T) {
+	a := New([]int{1}, map[string]int{"a": 1}, 2)
+	expect(t, a.IndexOf(2, 0), 2)
+	expect(t, a.IndexOf([]int{1}, 0), -1)
+	expect(t, a.LastIndexOf([]int{1}, -1), -1)
+	expect(t, a.Includes(map[string]int{"a": 1}), false)
+	expect(t, a.IndexOfFunc([]int{1}, 0, DeepEqual), 0)
+	expect(t, a.IncludesFunc(map[string]int{"a": 1}, DeepEqual), true)
+	caseInsensitive := func(x, y interface{}) bool {
+		s, ok := x.(string)
+		return ok && strings.EqualFold(s, y.(string))
+	}
+	expect(t, New("a", "B").IndexOfFunc("b", 0, caseInsensitive), 1)
+}
+
+func TestEqual(t *testing.T) {
+	type fixture struct {
+		a, b     ArrayInterface
+		expected bool
+	}
+	for _, fix := range []fixture{
+		{New(), New(), true},
+		{New(1, "a"), New(1, "a"), true},
+		{New(1, 2), New(1), false},
+		{New(1, New(2, []int{3})), New(1, New(2, []int{3})), true},
+		{New(1, New(2, []int{3})), New(1, New(2, []int{4})), false},
+		{New(map[string]int{"a": 1}), New(map[string]int{"a": 1}), true},
+	} {
+		expect(t, fix.a.Equal(fix.b), fix.expected)
+	}
+
+	// same content stored at different offsets of the ring buffer
+	a, b := New(2), New(1, 2)
+	a.Unshift(1)
+	b.Push(3)
+	b.Pop()
+	expect(t, New(a).Equal(New(b)), true)
+}
+
+func TestDeepEqualNestedInContainers(t *testing.T) {
+	// same content with a different ring buffer layout
+	shifted, pushed := New(0, 1, 2), New(1)
+	shifted.Shift()
+	pushed.Push(2)
+	type record struct {
+		Name  string
+		Items ArrayInterface
+	}
+	type fixture struct {
+		a, b     interface{}
+		expected bool
+	}
+	for _, fix := range []fixture{
+		{[]interface{}{shifted}, []interface{}{pushed}, true},
+		{[]ArrayInterface{shifted}, []ArrayInterface{pushed}, true},
+		{map[string]ArrayInterface{"a": shifted}, map[string]ArrayInterface{"a": pushed}, true},
+		{record{"a", shifted}, record{"a", pushed}, true},
+		{&record{"a", shifted}, &record{"a", pushed}, true},
+		{[]interface{}{shifted}, []interface{}{New(1)}, false},
+		{record{"a", shifted}, record{"b", pushed}, false},
+		{[]interface{}{shifted, nil}, []interface{}{pushed, nil}, true},
+		{map[string]int{"a": 1}, map[string]int{"b": 1}, false},
+		{[]int(nil), []int{}, false},
+		{1, int64(1), false},
+	} {
+		if DeepEqual(fix.a, fix.b) != fix.expected {
+			t.Error(fix.a, fix.b, "deep equality should be", fix.expected)
+		}
+	}
+
+	// slices sharing a backing array but with different lengths
+	shared := []interface{}{1, 2}
+	expect(t, DeepEqual([]interface{}{shared[:1], shared[:2]}, []interface{}{shared[:1], shared[:1]}), false)
+	expect(t, New(shared[:1], shared[:2]).Unique().Length(), 2)
+
+	// arrays in unexported fields
+	type hidden struct {
+		items ArrayInterface
+		array *Array
+	}
+	expect(t, DeepEqual(hidden{shifted, shifted.(*Array)}, hidden{pushed, pushed.(*Array)}), true)
+	expect(t, DeepEqual(hidden{shifted, nil}, hidden{New(9), nil}), false)
+	expect(t, DeepEqual(hidden{nil, nil}, hidden{nil, nil}), true)
+
+	cyclic := []interface{}{nil}
+	cyclic[0] = cyclic
+	expect(t, DeepEqual(cyclic, cyclic), true)
+}
+
+func TestCompare(t *testing.T) {
+	type fixture struct {
+		a, b     ArrayInterface
+		expected int
+	}
+	for _, fix := range []fixture{
+		{New(), New(), 0},
+		{New(1, 2), New(1, 3), -1},
+		{New(1, 2), New(1), 1},
+		{New(1), New(1, 0), -1},
+		{New(New(1, 2), 0), New(New(1, 1), 9), 1},
+		{New("a", 1), New("a", 1.0), 0},
+		{New(nil), New(false), -1},
+		{New(true), New(1), -1},
+		{New(1), New("1"), -1},
+		{New("z"), New(New()), -1},
+	} {
+		expect(t, fix.a.Compare(fix.b, nil), fix.expected)
+	}
+	expect(t, New(1, 2).Compare(New(1, 3), Descending(CompareNumbers)), 1)
+}
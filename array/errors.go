@@ -0,0 +1,102 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrIndexOutOfRange is returned when an index is outside of the array
+	ErrIndexOutOfRange = errors.New("array: index out of range")
+	// ErrEmpty is returned when reading from an empty array
+	ErrEmpty = errors.New("array: empty array")
+	// ErrUnsupportedType is returned when a value can't be turned into an array
+	ErrUnsupportedType = errors.New("array: unsupported collection type")
+	// ErrTypeMismatch is returned when an element doesn't have the expected type
+	ErrTypeMismatch = errors.New("array: element type mismatch")
+	// ErrNotNumeric is returned when a numeric operation meets a value which is not a number
+	ErrNotNumeric = errors.New("array: element is not a number")
+	// ErrNotEnoughElements is returned when a statistic needs more elements than the array has
+	ErrNotEnoughElements = errors.New("array: not enough elements")
+	// ErrNoTransaction is returned when committing or rolling back without a transaction
+	ErrNoTransaction = errors.New("array: no transaction in progress")
+)
+
+// IndexError is returned when accessing an index outside of the array.
+// It matches ErrIndexOutOfRange with errors.Is
+type IndexError struct {
+	Index  int
+	Length int
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("array: index %d out of range for length %d", e.Index, e.Length)
+}
+
+// Unwrap returns ErrIndexOutOfRange
+func (e *IndexError) Unwrap() error {
+	return ErrIndexOutOfRange
+}
+
+// ElementTypeError is returned when an element can't be converted to Type.
+// It matches ErrTypeMismatch with errors.Is
+type ElementTypeError struct {
+	Index int
+	Value interface{}
+	Type  reflect.Type
+}
+
+func (e *ElementTypeError) Error() string {
+	return fmt.Sprintf("array: element %d of type %T is not assignable to %s", e.Index, e.Value, e.Type)
+}
+
+// Unwrap returns ErrTypeMismatch
+func (e *ElementTypeError) Unwrap() error {
+	return ErrTypeMismatch
+}
+
+// NumericError is returned when an element is not a number.
+// It matches ErrNotNumeric with errors.Is
+type NumericError struct {
+	Index int
+	Value interface{}
+}
+
+func (e *NumericError) Error() string {
+	return fmt.Sprintf("array: element %d %+v of type %T is not a number", e.Index, e.Value, e.Value)
+}
+
+// Unwrap returns ErrNotNumeric
+func (e *NumericError) Unwrap() error {
+	return ErrNotNumeric
+}
+
+// ElementError is returned when a callback fails on an element of an array
+type ElementError struct {
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("array: element %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error returned by the callback
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is a panic recovered from a callback
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("array: callback panicked: %v", e.Value)
+}
@@ -0,0 +1,120 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format implements fmt.Formatter. Elements are formatted with the verb and flags
+// of the array, so %v, %+v, %q or %.2f print ArrayInterface[...] with elements
+// formatted accordingly, except %s which formats elements with %v like String does.
+// %#v prints Go syntax such as array.New(1, "a")
+func (a *Array) Format(f fmt.State, verb rune) {
+	if verb == 's' {
+		verb = 'v'
+	}
+	format := fmt.FormatString(f, verb)
+	prefix, suffix := "ArrayInterface[", "]"
+	if verb == 'v' && f.Flag('#') {
+		prefix, suffix = "array.New(", ")"
+	}
+	var builder strings.Builder
+	builder.WriteString(prefix)
+	for i := 0; i < a.length; i++ {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		fmt.Fprintf(&builder, format, a.get(i))
+	}
+	builder.WriteString(suffix)
+	f.Write([]byte(builder.String()))
+}
+
+// FormatOptions configures Array.FormatWith
+type FormatOptions struct {
+	// Verb formats elements, defaults to "%v"
+	Verb string
+	// Separator is written between elements, defaults to ", "
+	Separator string
+	// NoSeparator joins elements without any separator, ignoring Separator
+	NoSeparator bool
+	// MaxElements is the maximum number of elements written per array,
+	// remaining elements are replaced with Ellipsis. 0 means no limit
+	MaxElements int
+	// Ellipsis replaces elements beyond MaxElements, defaults to "..."
+	Ellipsis string
+	// Indent, when not empty, writes each element on its own line,
+	// indenting nested arrays once more than their parent. Trailing spaces
+	// of the separator are then dropped, so ", " ends lines with ","
+	Indent string
+}
+
+// FormatWith returns a representation of the array configured by opts, such as
+//
+//	New(1, 2, 3).FormatWith(FormatOptions{MaxElements: 2}) // [1, 2, ...]
+func (a *Array) FormatWith(opts FormatOptions) string {
+	if opts.Verb == "" {
+		opts.Verb = "%v"
+	}
+	if opts.Ellipsis == "" {
+		opts.Ellipsis = "..."
+	}
+	var builder strings.Builder
+	separator := opts.Separator
+	switch {
+	case opts.NoSeparator:
+		separator = ""
+	case separator == "":
+		separator = ", "
+	}
+	if opts.Indent != "" {
+		separator = strings.TrimRight(separator, " ")
+	}
+	formatArray(&builder, a, opts, separator, 0)
+	return builder.String()
+}
+
+// formatArray writes a at the given nesting depth, with separator between elements
+func formatArray(builder *strings.Builder, a ArrayInterface, opts FormatOptions, separator string, depth int) {
+	newline := func(depth int) {
+		if opts.Indent != "" {
+			builder.WriteByte('\n')
+			builder.WriteString(strings.Repeat(opts.Indent, depth))
+		}
+	}
+	builder.WriteByte('[')
+	length := a.Length()
+	for i, value := range a.All() {
+		if i > 0 {
+			builder.WriteString(separator)
+		}
+		newline(depth + 1)
+		if opts.MaxElements > 0 && i >= opts.MaxElements {
+			builder.WriteString(opts.Ellipsis)
+			break
+		}
+		if nested, ok := value.(ArrayInterface); ok {
+			formatArray(builder, nested, opts, separator, depth+1)
+		} else {
+			fmt.Fprintf(builder, opts.Verb, value)
+		}
+	}
+	if length > 0 {
+		newline(depth)
+	}
+	builder.WriteByte(']')
+}
+
+// Format implements fmt.Formatter on a snapshot of the array, see Array.Format
+func (s *SyncArray) Format(f fmt.State, verb rune) {
+	s.Snapshot().Format(f, verb)
+}
+
+// FormatWith returns a representation of a snapshot of the array, see Array.FormatWith
+func (s *SyncArray) FormatWith(opts FormatOptions) string {
+	return s.Snapshot().FormatWith(opts)
+}
@@ -0,0 +1,56 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	type point struct{ X, Y int }
+	a := New(1, "a", point{1, 2}, New(2.5))
+	type fixture struct {
+		format   string
+		expected string
+	}
+	for _, fix := range []fixture{
+		{"%v", "ArrayInterface[1, a, {1 2}, ArrayInterface[2.5]]"},
+		{"%s", "ArrayInterface[1, a, {1 2}, ArrayInterface[2.5]]"},
+		{"%+s", "ArrayInterface[1, a, {X:1 Y:2}, ArrayInterface[2.5]]"},
+		{"%+v", "ArrayInterface[1, a, {X:1 Y:2}, ArrayInterface[2.5]]"},
+		{"%#v", `array.New(1, "a", array.point{X:1, Y:2}, array.New(2.5))`},
+		{"%q", `ArrayInterface['\x01', "a", {'\x01' '\x02'}, ArrayInterface[%!q(float64=2.5)]]`},
+	} {
+		expect(t, fmt.Sprintf(fix.format, a), fix.expected)
+	}
+	expect(t, fmt.Sprintf("%.1f", New(1.25, 2.0)), "ArrayInterface[1.2, 2.0]")
+	expect(t, fmt.Sprintf("%q", New("a", "b")), `ArrayInterface["a", "b"]`)
+	expect(t, a.String(), "ArrayInterface[1, a, {X:1 Y:2}, ArrayInterface[2.5]]")
+	expect(t, New().String(), "ArrayInterface[]")
+	expect(t, fmt.Sprint(NewSync(1, 2)), "ArrayInterface[1, 2]")
+}
+
+func TestFormatWith(t *testing.T) {
+	a := New(1, 2, New(3, 4, 5), 6)
+	type fixture struct {
+		opts     FormatOptions
+		expected string
+	}
+	for _, fix := range []fixture{
+		{FormatOptions{}, "[1, 2, [3, 4, 5], 6]"},
+		{FormatOptions{Separator: "|"}, "[1|2|[3|4|5]|6]"},
+		{FormatOptions{NoSeparator: true}, "[12[345]6]"},
+		{FormatOptions{NoSeparator: true, Indent: " "}, "[\n 1\n 2\n [\n  3\n  4\n  5\n ]\n 6\n]"},
+		{FormatOptions{MaxElements: 2}, "[1, 2, ...]"},
+		{FormatOptions{MaxElements: 3, Ellipsis: "…"}, "[1, 2, [3, 4, 5], …]"},
+		{FormatOptions{Verb: "%03d", MaxElements: 4}, "[001, 002, [003, 004, 005], 006]"},
+		{FormatOptions{Indent: "  ", MaxElements: 3}, "[\n  1,\n  2,\n  [\n    3,\n    4,\n    5\n  ],\n  ...\n]"},
+	} {
+		expect(t, a.(*Array).FormatWith(fix.opts), fix.expected)
+	}
+	expect(t, New().(*Array).FormatWith(FormatOptions{Indent: "\t"}), "[]")
+	expect(t, NewSync(1).FormatWith(FormatOptions{}), "[1]")
+}
@@ -0,0 +1,125 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"reflect"
+)
+
+// arrayInterfaceType is the reflect.Type of ArrayInterface
+var arrayInterfaceType = reflect.TypeOf((*ArrayInterface)(nil)).Elem()
+
+// GroupBy groups the elements of an array by the result of key, in the order keys
+// are first seen. Keys are compared like KeyMap does, so slices or arrays can be keys:
+//
+//	groups := GroupBy(orders, func(v interface{}) interface{} { return v.(*Order).Customer })
+//	for customer, orders := range groups.All() {
+//		fmt.Println(customer, orders.Length())
+//	}
+func GroupBy(a ArrayInterface, key func(interface{}) interface{}) *KeyMap[ArrayInterface] {
+	groups := &KeyMap[ArrayInterface]{}
+	for value := range a.Values() {
+		group := groups.entry(key(value))
+		if *group == nil {
+			*group = &Array{}
+		}
+		(*group).Push(value)
+	}
+	return groups
+}
+
+// Partition splits an array into the elements satisfying predicate and the others,
+// keeping their order
+func Partition(a ArrayInterface, predicate func(value interface{}, i int) bool) (matched ArrayInterface, rest ArrayInterface) {
+	matched, rest = &Array{}, &Array{}
+	i := 0
+	for value := range a.Values() {
+		if predicate(value, i) {
+			matched.Push(value)
+		} else {
+			rest.Push(value)
+		}
+		i++
+	}
+	return matched, rest
+}
+
+// CountBy counts the elements of an array for each result of key,
+// keys are compared like KeyMap does
+func CountBy(a ArrayInterface, key func(interface{}) interface{}) *KeyMap[int] {
+	counts := &KeyMap[int]{}
+	for value := range a.Values() {
+		*counts.entry(key(value))++
+	}
+	return counts
+}
+
+// KeyBy indexes the elements of an array by the result of key,
+// the last element wins when several share a key. Keys are compared like KeyMap does
+func KeyBy(a ArrayInterface, key func(interface{}) interface{}) *KeyMap[interface{}] {
+	index := &KeyMap[interface{}]{}
+	for value := range a.Values() {
+		index.Set(key(value), value)
+	}
+	return index
+}
+
+// Chunk splits an array into arrays of size elements, the last one may be shorter
+//
+// CAN PANIC if size is less than 1
+func Chunk(a ArrayInterface, size int) ArrayInterface {
+	return NewQuery(a).Chunk(size).ToArray()
+}
+
+// Zip returns an array of arrays where the nth array holds the nth element of each
+// array. The result is as long as the shortest array
+func Zip(arrays ...ArrayInterface) ArrayInterface {
+	if len(arrays) == 0 {
+		return &Array{}
+	}
+	length := arrays[0].Length()
+	for _, a := range arrays[1:] {
+		length = min(length, a.Length())
+	}
+	result := NewWithCapacity(length)
+	for i := 0; i < length; i++ {
+		tuple := NewWithCapacity(len(arrays))
+		for _, a := range arrays {
+			tuple.Push(a.At(i))
+		}
+		result.Push(tuple)
+	}
+	return result
+}
+
+// Unzip is the reverse of Zip, it turns an array of arrays into arrays where the
+// nth array holds the nth element of each array. Shorter arrays are padded with nil.
+// Returns an *ElementTypeError if an element is not an ArrayInterface
+func Unzip(a ArrayInterface) ([]ArrayInterface, error) {
+	var result []ArrayInterface
+	i := 0
+	for value := range a.Values() {
+		tuple, ok := value.(ArrayInterface)
+		if !ok {
+			return nil, &ElementTypeError{Index: i, Value: value, Type: arrayInterfaceType}
+		}
+		for len(result) < tuple.Length() {
+			padding := NewWithCapacity(a.Length())
+			for j := 0; j < i; j++ {
+				padding.Push(nil)
+			}
+			result = append(result, padding)
+		}
+		for j, column := range result {
+			if j < tuple.Length() {
+				column.Push(tuple.At(j))
+			} else {
+				column.Push(nil)
+			}
+		}
+		i++
+	}
+	return result, nil
+}
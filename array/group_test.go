@@ -0,0 +1,153 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"testing"
+)
+
+// records are [name, team, score] arrays
+func records() ArrayInterface {
+	return New(
+		New("ann", "red", 3),
+		New("bob", "blue", 5),
+		New("cid", "red", 4),
+		New("dan", "green", 1),
+		New("eve", "blue", 2),
+	)
+}
+
+func team(v interface{}) interface{} { return v.(ArrayInterface).At(1) }
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy(records(), team)
+	expect(t, groups.Keys(), []interface{}{"red", "blue", "green"})
+	expect(t, groups.Len(), 3)
+	red, ok := groups.Get("red")
+	expect(t, ok, true)
+	expect(t, red, New(New("ann", "red", 3), New("cid", "red", 4)))
+	_, ok = groups.Get("yellow")
+	expect(t, ok, false)
+
+	expect(t, GroupBy(New(), team).Len(), 0)
+}
+
+func TestGroupByUnhashableKeys(t *testing.T) {
+	// slice keys are compared by content instead of panicking
+	bySlice := GroupBy(records(), func(v interface{}) interface{} {
+		return []interface{}{v.(ArrayInterface).At(1)}
+	})
+	expect(t, bySlice.Keys(), []interface{}{[]interface{}{"red"}, []interface{}{"blue"}, []interface{}{"green"}})
+	blue, _ := bySlice.Get([]interface{}{"blue"})
+	expect(t, blue.Length(), 2)
+
+	// array keys are compared by content, not by pointer
+	byArray := GroupBy(records(), func(v interface{}) interface{} {
+		return v.(ArrayInterface).Slice(1, 2)
+	})
+	expect(t, byArray.Len(), 3)
+	red, ok := byArray.Get(New("red"))
+	expect(t, ok, true)
+	expect(t, red.Length(), 2)
+
+	// all grouping APIs agree
+	counts := CountBy(records(), func(v interface{}) interface{} { return v.(ArrayInterface).Slice(1, 2) })
+	count, _ := counts.Get(New("blue"))
+	expect(t, count, 2)
+	index := KeyBy(records(), func(v interface{}) interface{} { return []string{v.(ArrayInterface).At(1).(string)} })
+	last, _ := index.Get([]string{"red"})
+	expect(t, last, New("cid", "red", 4))
+	queryGroups := NewQuery(records()).GroupBy(func(v interface{}) interface{} { return v.(ArrayInterface).Slice(1, 2) }).Count()
+	expect(t, queryGroups, byArray.Len())
+}
+
+func TestPartition(t *testing.T) {
+	high, low := Partition(records(), func(v interface{}, i int) bool {
+		return v.(ArrayInterface).At(2).(int) >= 3
+	})
+	expect(t, high.Map(func(v interface{}, i int) interface{} { return v.(ArrayInterface).At(0) }), New("ann", "bob", "cid"))
+	expect(t, low.Length(), 2)
+	expect(t, low.At(1), New("eve", "blue", 2))
+}
+
+func TestCountByKeyBy(t *testing.T) {
+	counts := CountBy(records(), team)
+	expect(t, counts.Keys(), []interface{}{"red", "blue", "green"})
+	for key, count := range map[string]int{"red": 2, "blue": 2, "green": 1} {
+		actual, _ := counts.Get(key)
+		expect(t, actual, count)
+	}
+	index := KeyBy(records(), team)
+	expect(t, index.Len(), 3)
+	blue, _ := index.Get("blue")
+	expect(t, blue, New("eve", "blue", 2))
+}
+
+func TestKeyMap(t *testing.T) {
+	var m KeyMap[string]
+	m.Set(2, "two")
+	m.Set([]int{1}, "slice")
+	m.Set(New(1), "array")
+	m.Set(2, "deux")
+	expect(t, m.Len(), 3)
+	value, _ := m.Get(2)
+	expect(t, value, "deux")
+	value, _ = m.Get([]int{1})
+	expect(t, value, "slice")
+	var values []string
+	for _, v := range m.All() {
+		values = append(values, v)
+	}
+	expect(t, values, []string{"deux", "slice", "array"})
+	_, ok := m.Get(int64(2))
+	expect(t, ok, false)
+}
+
+func TestChunk(t *testing.T) {
+	expect(t, Chunk(New(1, 2, 3, 4, 5), 2), New(New(1, 2), New(3, 4), New(5)))
+	expect(t, Chunk(New(), 2), New())
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk with size 0 should panic")
+		}
+	}()
+	Chunk(New(1), 0)
+}
+
+func TestZipUnzip(t *testing.T) {
+	names, teams := New("ann", "bob", "cid"), New("red", "blue")
+	zipped := Zip(names, teams, New(New(1), New(2), New(3)))
+	expect(t, zipped, New(New("ann", "red", New(1)), New("bob", "blue", New(2))))
+	expect(t, Zip(), New())
+
+	columns, err := Unzip(zipped)
+	expect(t, err, nil)
+	expectColumns(t, columns, New("ann", "bob"), New("red", "blue"), New(New(1), New(2)))
+
+	columns, err = Unzip(New(New(1), New(2, "b"), New()))
+	expect(t, err, nil)
+	expectColumns(t, columns, New(1, 2, nil), New(nil, "b", nil))
+
+	columns, err = Unzip(New())
+	expect(t, len(columns), 0)
+	expect(t, err, nil)
+
+	_, err = Unzip(New(New(1), 2))
+	var typeErr *ElementTypeError
+	if !errors.As(err, &typeErr) || typeErr.Index != 1 || !errors.Is(err, ErrTypeMismatch) {
+		t.Error(err, "should be an *ElementTypeError for index 1")
+	}
+}
+
+func expectColumns(t *testing.T, actual []ArrayInterface, expected ...ArrayInterface) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatal(actual, "should have length", len(expected))
+	}
+	for i, column := range expected {
+		expect(t, actual[i], column)
+	}
+}
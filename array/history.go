@@ -0,0 +1,262 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"slices"
+)
+
+// HistoryOptions configures the history of a TransactionalArray
+type HistoryOptions struct {
+	// Depth is the maximum number of steps which can be undone, 0 means no limit
+	Depth int
+	// MaxRetainedValues is the maximum number of values the history keeps references to,
+	// 0 means no limit. It counts values, not bytes: an insertion or a removal retains
+	// 1 value and an update 2, whatever their size. The oldest steps are dropped when
+	// it is exceeded, except the changes of an open transaction which are kept until it
+	// ends so it can be rolled back: a long transaction can exceed the limit
+	MaxRetainedValues int
+}
+
+// HistoryStats describes the history of a TransactionalArray
+type HistoryStats struct {
+	// UndoSteps and RedoSteps are the numbers of steps which can be undone and redone
+	UndoSteps, RedoSteps int
+	// RetainedValues is the number of values referenced by the history and
+	// the current transaction, which can't be garbage collected
+	RetainedValues int
+}
+
+// TransactionalArray is an ObservableArray which records its changes so they can be
+// rolled back or undone.
+//
+//	list := NewTransactional(New(1, 2), HistoryOptions{Depth: 50})
+//	list.Begin()
+//	list.Push(3)
+//	list.Splice(0, 1)
+//	if !valid(list) {
+//		list.Rollback() // list is [1, 2] again
+//	} else {
+//		list.Commit()
+//	}
+//	list.Undo() // undoes the whole transaction
+//
+// Every mutating call, or every Batch, outside of a transaction is an undo step,
+// a committed transaction is a single step. Changes made while a transaction is open
+// can't be undone with Undo until it is committed. Changes are recorded as they happen,
+// so subscribers can roll back or undo them while being notified.
+// A TransactionalArray is not safe for concurrent use.
+type TransactionalArray struct {
+	*ObservableArray
+	options     HistoryOptions
+	undo, redo  [][]Change
+	transaction []Change
+	savepoints  []int
+	// step holds the changes of the running batch
+	step     []Change
+	retained int
+}
+
+// NewTransactional returns a transactional array wrapping a, or a new array if a is nil
+func NewTransactional(a ArrayInterface, options HistoryOptions) *TransactionalArray {
+	t := &TransactionalArray{ObservableArray: NewObservable(a), options: options}
+	t.recorder = t.record
+	t.batchEnd = t.endBatch
+	return t
+}
+
+// record records the changes of a mutating call of the observable array
+func (t *TransactionalArray) record(changes []Change) {
+	t.retained += retainedValues(changes)
+	switch {
+	case len(t.savepoints) > 0:
+		t.transaction = append(t.transaction, changes...)
+		t.trim()
+	case t.batching > 0:
+		t.step = append(t.step, changes...)
+	default:
+		t.push(slices.Clone(changes))
+	}
+}
+
+// endBatch makes the changes of the batch which just ended a single undo step,
+// before subscribers are notified so they can undo it
+func (t *TransactionalArray) endBatch() {
+	if len(t.step) > 0 {
+		step := t.step
+		t.step = nil
+		t.push(step)
+	}
+}
+
+// push adds a step to the undo stack, clearing the redo stack
+func (t *TransactionalArray) push(changes []Change) {
+	t.undo = append(t.undo, changes)
+	for _, step := range t.redo {
+		t.retained -= retainedValues(step)
+	}
+	clear(t.redo)
+	t.redo = t.redo[:0]
+	t.trim()
+}
+
+// trim drops the oldest steps until the history fits in its options
+func (t *TransactionalArray) trim() {
+	for len(t.undo) > 0 && (t.options.Depth > 0 && len(t.undo) > t.options.Depth ||
+		t.options.MaxRetainedValues > 0 && t.retained > t.options.MaxRetainedValues) {
+		t.retained -= retainedValues(t.undo[0])
+		t.undo[0] = nil
+		t.undo = t.undo[1:]
+	}
+	for len(t.redo) > 0 && t.options.MaxRetainedValues > 0 && t.retained > t.options.MaxRetainedValues {
+		t.retained -= retainedValues(t.redo[0])
+		t.redo[0] = nil
+		t.redo = t.redo[1:]
+	}
+}
+
+// retainedValues returns the number of values referenced by changes
+func retainedValues(changes []Change) int {
+	n := 0
+	for _, change := range changes {
+		if change.Kind == UpdateChange {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// replay applies changes, or their inverses in reverse order if inverse is true,
+// to the wrapped array and notifies them as a single slice without recording them.
+// The changes subscribers make in reaction are recorded as usual
+func (t *TransactionalArray) replay(changes []Change, inverse bool) {
+	applied := make([]Change, len(changes))
+	for i, change := range changes {
+		if inverse {
+			change = changes[len(changes)-1-i].Inverse()
+		}
+		change.Apply(t.Unwrap())
+		applied[i] = change
+	}
+	t.notify(applied)
+}
+
+// Begin starts a transaction. Transactions can be nested,
+// only the outermost Commit adds an undo step
+//
+// CAN PANIC if called inside a Batch
+func (t *TransactionalArray) Begin() {
+	t.checkBatch()
+	t.savepoints = append(t.savepoints, len(t.transaction))
+}
+
+// Commit ends the innermost transaction, keeping its changes.
+// Returns ErrNoTransaction if no transaction is in progress
+//
+// CAN PANIC if called inside a Batch
+func (t *TransactionalArray) Commit() error {
+	t.checkBatch()
+	if len(t.savepoints) == 0 {
+		return ErrNoTransaction
+	}
+	t.savepoints = t.savepoints[:len(t.savepoints)-1]
+	if len(t.savepoints) == 0 && len(t.transaction) > 0 {
+		changes := t.transaction
+		t.transaction = nil
+		t.push(changes)
+	}
+	return nil
+}
+
+// Rollback ends the innermost transaction, undoing its changes.
+// Returns ErrNoTransaction if no transaction is in progress
+//
+// CAN PANIC if called inside a Batch
+func (t *TransactionalArray) Rollback() error {
+	t.checkBatch()
+	if len(t.savepoints) == 0 {
+		return ErrNoTransaction
+	}
+	savepoint := t.savepoints[len(t.savepoints)-1]
+	t.savepoints = t.savepoints[:len(t.savepoints)-1]
+	changes := slices.Clone(t.transaction[savepoint:])
+	t.retained -= retainedValues(changes)
+	clear(t.transaction[savepoint:])
+	t.transaction = t.transaction[:savepoint]
+	t.replay(changes, true)
+	return nil
+}
+
+// checkBatch panics if a batch is running, its changes would be recorded
+// after the transaction boundary or the undone step
+func (t *TransactionalArray) checkBatch() {
+	if t.batching > 0 {
+		panic("array: transactions can't begin or end and steps can't be undone inside a batch")
+	}
+}
+
+// InTransaction returns true if a transaction is in progress
+func (t *TransactionalArray) InTransaction() bool {
+	return len(t.savepoints) > 0
+}
+
+// CanUndo returns true if there is a step to undo and no transaction in progress
+func (t *TransactionalArray) CanUndo() bool {
+	return len(t.undo) > 0 && !t.InTransaction()
+}
+
+// CanRedo returns true if there is a step to redo and no transaction in progress
+func (t *TransactionalArray) CanRedo() bool {
+	return len(t.redo) > 0 && !t.InTransaction()
+}
+
+// Undo undoes the last step, returns false if CanUndo is false
+//
+// CAN PANIC if called inside a Batch
+func (t *TransactionalArray) Undo() bool {
+	t.checkBatch()
+	if !t.CanUndo() {
+		return false
+	}
+	changes := t.undo[len(t.undo)-1]
+	t.undo[len(t.undo)-1] = nil
+	t.undo = t.undo[:len(t.undo)-1]
+	t.redo = append(t.redo, changes)
+	t.replay(changes, true)
+	return true
+}
+
+// Redo redoes the last undone step, returns false if CanRedo is false
+//
+// CAN PANIC if called inside a Batch
+func (t *TransactionalArray) Redo() bool {
+	t.checkBatch()
+	if !t.CanRedo() {
+		return false
+	}
+	changes := t.redo[len(t.redo)-1]
+	t.redo[len(t.redo)-1] = nil
+	t.redo = t.redo[:len(t.redo)-1]
+	t.undo = append(t.undo, changes)
+	t.trim()
+	t.replay(changes, false)
+	return true
+}
+
+// ClearHistory forgets the steps to undo and redo, releasing the values they retain.
+// The current transaction and batch, if any, are kept
+func (t *TransactionalArray) ClearHistory() {
+	clear(t.undo)
+	clear(t.redo)
+	t.undo, t.redo = t.undo[:0], t.redo[:0]
+	t.retained = retainedValues(t.transaction) + retainedValues(t.step)
+}
+
+// Stats returns the size of the history
+func (t *TransactionalArray) Stats() HistoryStats {
+	return HistoryStats{UndoSteps: len(t.undo), RedoSteps: len(t.redo), RetainedValues: t.retained}
+}
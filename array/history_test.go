@@ -0,0 +1,275 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestTransactionalArrayRollback(t *testing.T) {
+	a := NewTransactional(New(1, 2, 3), HistoryOptions{})
+	a.Begin()
+	a.Push(4)
+	a.Splice(0, 2, "a", "b", "c")
+	a.SortInPlace(CompareNatural)
+	expect(t, a.InTransaction(), true)
+	expect(t, a.CanUndo(), false)
+	expect(t, a.Rollback(), nil)
+	expectArray(t, a, 1, 2, 3)
+	expect(t, a.Stats(), HistoryStats{})
+
+	if err := a.Commit(); !errors.Is(err, ErrNoTransaction) {
+		t.Error(err, "should be", ErrNoTransaction)
+	}
+	if err := a.Rollback(); !errors.Is(err, ErrNoTransaction) {
+		t.Error(err, "should be", ErrNoTransaction)
+	}
+}
+
+func TestTransactionalArrayNestedTransactions(t *testing.T) {
+	a := NewTransactional(New(1), HistoryOptions{})
+	a.Begin()
+	a.Push(2)
+	a.Begin()
+	a.Push(3)
+	expect(t, a.Rollback(), nil)
+	a.Begin()
+	a.Push(4)
+	expect(t, a.Commit(), nil)
+	expectArray(t, a, 1, 2, 4)
+	expect(t, a.Commit(), nil)
+	expect(t, a.Stats().UndoSteps, 1)
+
+	// a committed transaction is undone in a single step
+	expect(t, a.Undo(), true)
+	expectArray(t, a, 1)
+	expect(t, a.Redo(), true)
+	expectArray(t, a, 1, 2, 4)
+}
+
+func TestTransactionalArrayUndoRedo(t *testing.T) {
+	a := NewTransactional(nil, HistoryOptions{})
+	a.Push(1, 2, 3)
+	a.Shift()
+	a.Fill(0)
+	a.Batch(func() {
+		a.Push(4)
+		a.Unshift(5)
+	})
+	expectArray(t, a, 5, 0, 0, 4)
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 4, RetainedValues: 3 + 1 + 4 + 2})
+
+	expect(t, a.Undo(), true)
+	expectArray(t, a, 0, 0)
+	expect(t, a.Undo(), true)
+	expectArray(t, a, 2, 3)
+	expect(t, a.Redo(), true)
+	expectArray(t, a, 0, 0)
+	expect(t, a.Stats().RedoSteps, 1)
+
+	// a new change clears the steps to redo
+	a.Pop()
+	expect(t, a.CanRedo(), false)
+	expect(t, a.Redo(), false)
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 4, RetainedValues: 3 + 1 + 4 + 1})
+
+	for a.Undo() {
+	}
+	expect(t, a.Length(), 0)
+	expect(t, a.Stats().RedoSteps, 4)
+	a.ClearHistory()
+	expect(t, a.Stats(), HistoryStats{})
+}
+
+func TestTransactionalArrayNotifiesUndo(t *testing.T) {
+	a := NewTransactional(New(1, 2), HistoryOptions{})
+	mirror := New(1, 2)
+	notifications := 0
+	a.Subscribe(func(changes []Change) {
+		notifications++
+		for _, change := range changes {
+			change.Apply(mirror)
+		}
+	})
+	a.Splice(0, 1, "a", "b")
+	a.Undo()
+	expect(t, notifications, 2)
+	expect(t, mirror, New(1, 2))
+}
+
+func TestTransactionalArrayLimits(t *testing.T) {
+	a := NewTransactional(nil, HistoryOptions{Depth: 3})
+	for i := 0; i < 10; i++ {
+		a.Push(i)
+	}
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 3, RetainedValues: 3})
+	for a.Undo() {
+	}
+	expect(t, a.Length(), 7)
+
+	a = NewTransactional(nil, HistoryOptions{MaxRetainedValues: 4})
+	a.Push(1, 2, 3)
+	a.Push(4)
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 2, RetainedValues: 4})
+	a.Push(5)
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 2, RetainedValues: 2})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Begin inside a batch should panic")
+		}
+	}()
+	a.Batch(func() { a.Begin() })
+}
+
+func TestTransactionalArrayUndoEverything(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	a := NewTransactional(New(1, 2, 3), HistoryOptions{})
+	var states []ArrayInterface
+	operations := []func(){
+		func() { a.Push(r.IntN(100)) },
+		func() { a.Pop() },
+		func() { a.Shift() },
+		func() { a.Unshift(r.IntN(100), r.IntN(100)) },
+		func() { a.Splice(r.IntN(6)-3, r.IntN(3), r.IntN(100)) },
+		func() { a.SortStable(CompareNumbers) },
+		func() { a.ShuffleInPlace(r) },
+		func() { a.CopyWithin(r.IntN(4), r.IntN(4)) },
+		func() { a.InsertSorted(r.IntN(100), CompareNumbers) },
+		func() { a.Truncate(r.IntN(10)) },
+		func() { a.Clear() },
+	}
+	for i := 0; i < 500; i++ {
+		state, steps := a.ToSpliced(0, 0), a.Stats().UndoSteps
+		operations[r.IntN(len(operations))]()
+		// operations which change nothing don't add a step
+		if a.Stats().UndoSteps > steps {
+			states = append(states, state)
+		}
+	}
+	for i := len(states) - 1; i >= 0; i-- {
+		expect(t, a.Undo(), true)
+		if !a.Equal(states[i]) {
+			t.Fatal(a, "should be", states[i], "after undoing step", i)
+		}
+	}
+	expect(t, a.Undo(), false)
+	expectArray(t, a, 1, 2, 3)
+}
+
+func TestTransactionalArrayUndoLargeRewrite(t *testing.T) {
+	a := NewTransactional(numbers(10000), HistoryOptions{})
+	mirror := numbers(10000)
+	notifications := 0
+	a.Subscribe(func(changes []Change) {
+		notifications++
+		for _, change := range changes {
+			change.Apply(mirror)
+		}
+	})
+	a.ShuffleInPlace(rand.NewPCG(1, 2))
+	expect(t, a.Undo(), true)
+	expect(t, notifications, 2)
+	expect(t, a.Equal(numbers(10000)), true)
+	expect(t, mirror.Equal(a), true)
+	expect(t, a.Redo(), true)
+	expect(t, mirror.Equal(a), true)
+}
+
+func TestTransactionalArrayRollbackFromSubscriber(t *testing.T) {
+	a := NewTransactional(New(1, 2), HistoryOptions{})
+	a.Subscribe(func(changes []Change) {
+		for _, change := range changes {
+			if change.Kind == InsertChange && change.NewValue.(int) < 0 && a.InTransaction() {
+				expect(t, a.Rollback(), nil)
+				return
+			}
+		}
+	})
+	a.Begin()
+	a.Push(3)
+	a.Push(-1)
+	expect(t, a.InTransaction(), false)
+	expectArray(t, a, 1, 2)
+	expect(t, a.Stats(), HistoryStats{})
+}
+
+func TestTransactionalArrayBatches(t *testing.T) {
+	a := NewTransactional(New(), HistoryOptions{})
+	a.Begin()
+	a.Batch(func() {
+		a.Push(1)
+		a.Push(2)
+	})
+	a.Push(3)
+	expect(t, a.Stats().UndoSteps, 0)
+	expect(t, a.Commit(), nil)
+	a.Batch(func() {
+		a.Shift()
+		a.Batch(func() { a.Shift() })
+	})
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 2, RetainedValues: 5})
+	expect(t, a.Undo(), true)
+	expectArray(t, a, 1, 2, 3)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Undo inside a batch should panic")
+		}
+	}()
+	a.Batch(func() { a.Undo() })
+}
+
+func TestTransactionalArrayUndoBatchFromSubscriber(t *testing.T) {
+	a := NewTransactional(New(1), HistoryOptions{})
+	a.Push(2)
+	a.Subscribe(func(changes []Change) {
+		if len(changes) == 2 && changes[0].Kind == InsertChange {
+			a.Undo()
+		}
+	})
+	a.Batch(func() {
+		a.Push(3)
+		a.Push(4)
+	})
+	expectArray(t, a, 1, 2)
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 1, RedoSteps: 1, RetainedValues: 3})
+}
+
+func TestTransactionalArrayRecordsReactionsToUndo(t *testing.T) {
+	a := NewTransactional(New(), HistoryOptions{})
+	a.Push("a")
+	a.Push("b")
+	a.Subscribe(func(changes []Change) {
+		for _, change := range changes {
+			if change.Kind == RemoveChange && change.OldValue == "b" {
+				a.Push("x")
+			}
+		}
+	})
+	expect(t, a.Undo(), true)
+	expectArray(t, a, "a", "x")
+	expect(t, a.Stats(), HistoryStats{UndoSteps: 2, RetainedValues: 2})
+	expect(t, a.Undo(), true)
+	expectArray(t, a, "a")
+}
+
+func TestTransactionalArrayLongTransactionLimit(t *testing.T) {
+	a := NewTransactional(New(), HistoryOptions{MaxRetainedValues: 3})
+	a.Push(1)
+	a.Push(2)
+	a.Begin()
+	a.Push(3, 4)
+	// the oldest step is dropped to make room for the transaction
+	expect(t, a.Stats().RetainedValues, 3)
+	a.Push(5, 6)
+	// the transaction is kept whole so it can be rolled back
+	expect(t, a.Stats(), HistoryStats{RetainedValues: 4})
+	expect(t, a.Rollback(), nil)
+	expectArray(t, a, 1, 2)
+	expect(t, a.Stats(), HistoryStats{})
+}
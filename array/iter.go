@@ -0,0 +1,71 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"iter"
+)
+
+// All returns an iterator over index and value pairs of the array
+func (a *Array) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i := 0; i < a.length; i++ {
+			if !yield(i, a.get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the array
+func (a *Array) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for i := 0; i < a.length; i++ {
+			if !yield(a.get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index and value pairs of the array,
+// from the last element to the first
+func (a *Array) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i := a.length - 1; i >= 0; i-- {
+			if i >= a.length {
+				continue
+			}
+			if !yield(i, a.get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq creates an Array from the values of an iterator
+func FromSeq[T any](seq iter.Seq[T]) ArrayInterface {
+	result := &Array{}
+	for value := range seq {
+		result.Push(value)
+	}
+	return result
+}
+
+// All returns an iterator over index and value pairs of a snapshot of the array
+func (s *SyncArray) All() iter.Seq2[int, interface{}] {
+	return s.Snapshot().All()
+}
+
+// Values returns an iterator over the values of a snapshot of the array
+func (s *SyncArray) Values() iter.Seq[interface{}] {
+	return s.Snapshot().Values()
+}
+
+// Backward returns an iterator over index and value pairs of a snapshot of the array,
+// from the last element to the first
+func (s *SyncArray) Backward() iter.Seq2[int, interface{}] {
+	return s.Snapshot().Backward()
+}
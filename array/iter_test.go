@@ -0,0 +1,64 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	a := New("a", "b", "c")
+	var indexes []int
+	var values []interface{}
+	for i, v := range a.All() {
+		if i == 2 {
+			break
+		}
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	expect(t, len(indexes), 2)
+	expect(t, indexes[1], 1)
+	expect(t, values[1], "b")
+}
+
+func TestValues(t *testing.T) {
+	a := New(1, 2, 3)
+	expectArray(t, New(slices.Collect(a.Values())...), 1, 2, 3)
+	expect(t, slices.ContainsFunc(slices.Collect(a.Values()), func(v interface{}) bool { return v == 2 }), true)
+}
+
+func TestBackward(t *testing.T) {
+	a := New(1, 2, 3)
+	var values []interface{}
+	for i, v := range a.Backward() {
+		expect(t, a.At(i), v)
+		values = append(values, v)
+		a.Pop()
+	}
+	expectArray(t, New(values...), 3, 2, 1)
+}
+
+func TestFromSeq(t *testing.T) {
+	expectArray(t, FromSeq(slices.Values([]int{1, 2})), 1, 2)
+	keys := FromSeq(maps.Keys(map[string]bool{"a": true}))
+	expectArray(t, keys, "a")
+	expectArray(t, FromSeq(New(1, 2).Values()), 1, 2)
+}
+
+func TestSyncArrayIterators(t *testing.T) {
+	s := NewSync(1, 2)
+	for _, v := range s.All() {
+		s.Push(v)
+	}
+	expectArray(t, s.Snapshot(), 1, 2, 1, 2)
+	expectArray(t, FromSeq(s.Values()), 1, 2, 1, 2)
+	for i := range s.Backward() {
+		expect(t, i, 3)
+		break
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+func init() {
+	gob.Register(&Array{})
+}
+
+// MarshalJSON encodes the array as a JSON array
+func (a *Array) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ArrayInterface())
+}
+
+// UnmarshalJSON replaces the content of the array with a JSON array.
+// Values are decoded like encoding/json does into an interface{},
+// except nested JSON arrays which become *Array.
+// Use a TypeRegistry to decode values into their concrete types
+func (a *Array) UnmarshalJSON(data []byte) error {
+	var values []interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	a.buffer, a.head, a.length = nil, 0, 0
+	for _, value := range values {
+		a.Push(fromJSON(value))
+	}
+	return nil
+}
+
+// fromJSON turns JSON arrays decoded by encoding/json into arrays
+func fromJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		result := &Array{}
+		for _, el := range value {
+			result.Push(fromJSON(el))
+		}
+		return result
+	case map[string]interface{}:
+		for key, el := range value {
+			value[key] = fromJSON(el)
+		}
+	}
+	return value
+}
+
+// GobEncode encodes the array with encoding/gob.
+// Like any interface value, the concrete types of the elements must be registered
+// with gob.Register, *Array is registered by this package
+func (a *Array) GobEncode() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(a.ArrayInterface()); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// GobDecode replaces the content of the array with data encoded by GobEncode
+func (a *Array) GobDecode(data []byte) error {
+	var values []interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return err
+	}
+	a.buffer, a.head, a.length = nil, 0, 0
+	a.Push(values...)
+	return nil
+}
+
+// MarshalBinary encodes the array with encoding/gob, see GobEncode
+func (a *Array) MarshalBinary() ([]byte, error) {
+	return a.GobEncode()
+}
+
+// UnmarshalBinary replaces the content of the array with data encoded by MarshalBinary
+func (a *Array) UnmarshalBinary(data []byte) error {
+	return a.GobDecode(data)
+}
+
+// MarshalText encodes the array as a JSON array, see MarshalJSON
+func (a *Array) MarshalText() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+// UnmarshalText replaces the content of the array with a JSON array, see UnmarshalJSON
+func (a *Array) UnmarshalText(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+// MarshalJSON encodes a snapshot of the array as a JSON array
+func (s *SyncArray) MarshalJSON() ([]byte, error) {
+	return s.Snapshot().MarshalJSON()
+}
+
+// UnmarshalJSON replaces the content of the array with a JSON array
+func (s *SyncArray) UnmarshalJSON(data []byte) error {
+	return s.replace(data, (*Array).UnmarshalJSON)
+}
+
+// MarshalText encodes the array as a JSON array
+func (s *SyncArray) MarshalText() (data []byte, err error) {
+	s.read(func(a *Array) { data, err = a.MarshalText() })
+	return data, err
+}
+
+// UnmarshalText replaces the content of the array with a JSON array
+func (s *SyncArray) UnmarshalText(data []byte) error {
+	return s.replace(data, (*Array).UnmarshalText)
+}
+
+// GobEncode encodes the array with encoding/gob, see Array.GobEncode
+func (s *SyncArray) GobEncode() (data []byte, err error) {
+	s.read(func(a *Array) { data, err = a.GobEncode() })
+	return data, err
+}
+
+// GobDecode replaces the content of the array with data encoded by GobEncode
+func (s *SyncArray) GobDecode(data []byte) error {
+	return s.replace(data, (*Array).GobDecode)
+}
+
+// MarshalBinary encodes the array with encoding/gob, see GobEncode
+func (s *SyncArray) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary replaces the content of the array with data encoded by MarshalBinary
+func (s *SyncArray) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// replace decodes data into a new array with decode, then swaps it in under the write lock
+func (s *SyncArray) replace(data []byte, decode func(*Array, []byte) error) error {
+	a := &Array{}
+	if err := decode(a, data); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.array = a
+	return nil
+}
+
+// TypeRegistry encodes arrays to JSON along with the names of the element types
+// so they can be decoded back into their concrete types.
+//
+//	registry := NewTypeRegistry()
+//	err := registry.Register("order", &Order{})
+//	data, err = registry.EncodeJSON(orders)
+//	// [{"type":"order","value":{"ID":1}},{"type":"int","value":2}]
+//	decoded, err := registry.DecodeJSON(data)
+//	// decoded.At(0) is an *Order, decoded.At(1) an int
+//
+// Elements of unregistered types are encoded without a type name
+// and decoded like Array.UnmarshalJSON does.
+type TypeRegistry struct {
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// typedValue is the JSON representation of an element encoded by a TypeRegistry
+type typedValue struct {
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+// arrayTypeName is the type name of nested arrays
+const arrayTypeName = "array"
+
+// NewTypeRegistry returns a registry where bool, string and numeric types are registered
+// under their Go names
+func NewTypeRegistry() *TypeRegistry {
+	registry := &TypeRegistry{types: map[string]reflect.Type{}, names: map[reflect.Type]string{}}
+	for _, value := range []interface{}{
+		false, "", 0, int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0), float32(0), float64(0),
+	} {
+		registry.Register(reflect.TypeOf(value).Name(), value)
+	}
+	return registry
+}
+
+// Register registers the type of value under name, value may be a pointer.
+// Returns an error if value is nil, or if name is "array" or already used by another type
+func (r *TypeRegistry) Register(name string, value interface{}) error {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return fmt.Errorf("%w: can't register the type of nil under %q", ErrUnsupportedType, name)
+	}
+	if name == arrayTypeName {
+		return fmt.Errorf("array: type name %q is reserved", name)
+	}
+	if registered, ok := r.types[name]; ok && registered != t {
+		return fmt.Errorf("array: type name %q already registered for %s", name, registered)
+	}
+	r.types[name] = t
+	r.names[t] = name
+	return nil
+}
+
+// EncodeJSON encodes the array as a JSON array of {"type": name, "value": value} objects,
+// nested arrays are encoded recursively
+func (r *TypeRegistry) EncodeJSON(a ArrayInterface) ([]byte, error) {
+	values, err := r.encode(a)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(values)
+}
+
+// encode turns the elements of an array into typed values
+func (r *TypeRegistry) encode(a ArrayInterface) ([]typedValue, error) {
+	values := make([]typedValue, 0, a.Length())
+	for value := range a.Values() {
+		var typed typedValue
+		var err error
+		if nested, ok := value.(ArrayInterface); ok {
+			var nestedValues []typedValue
+			if nestedValues, err = r.encode(nested); err != nil {
+				return nil, err
+			}
+			typed.Type = arrayTypeName
+			typed.Value, err = json.Marshal(nestedValues)
+		} else {
+			typed.Type = r.names[reflect.TypeOf(value)]
+			typed.Value, err = json.Marshal(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, typed)
+	}
+	return values, nil
+}
+
+// DecodeJSON decodes data encoded by EncodeJSON into a new array.
+// Returns an error wrapping ErrUnsupportedType for unregistered type names
+func (r *TypeRegistry) DecodeJSON(data []byte) (ArrayInterface, error) {
+	var values []typedValue
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	result := &Array{}
+	for _, typed := range values {
+		value, err := r.decode(typed)
+		if err != nil {
+			return nil, err
+		}
+		result.Push(value)
+	}
+	return result, nil
+}
+
+// decode turns a typed value back into a value of its registered type
+func (r *TypeRegistry) decode(typed typedValue) (interface{}, error) {
+	if typed.Type == "" {
+		var value interface{}
+		if err := json.Unmarshal(typed.Value, &value); err != nil {
+			return nil, err
+		}
+		return fromJSON(value), nil
+	}
+	if typed.Type == arrayTypeName {
+		return r.DecodeJSON(typed.Value)
+	}
+	t, ok := r.types[typed.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: type name %q is not registered", ErrUnsupportedType, typed.Type)
+	}
+	if t.Kind() == reflect.Pointer {
+		value := reflect.New(t.Elem())
+		if err := json.Unmarshal(typed.Value, value.Interface()); err != nil {
+			return nil, err
+		}
+		return value.Interface(), nil
+	}
+	value := reflect.New(t)
+	if err := json.Unmarshal(typed.Value, value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
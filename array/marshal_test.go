@@ -0,0 +1,181 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type order struct {
+	ID    int
+	Items []string
+}
+
+func init() {
+	gob.Register(order{})
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	a := New(1, "a", true, nil, New(2, New("b")), map[string]interface{}{"k": []interface{}{1}})
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, string(data), `[1,"a",true,null,[2,["b"]],{"k":[1]}]`)
+
+	decoded := New()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, decoded.Length(), 6)
+	expect(t, decoded.At(0), 1.0)
+	expect(t, decoded.At(4).(ArrayInterface).Equal(New(2.0, New("b"))), true)
+	expect(t, decoded.At(5).(map[string]interface{})["k"].(ArrayInterface).At(0), 1.0)
+
+	if err := json.Unmarshal([]byte(`{}`), decoded); err == nil {
+		t.Error("unmarshalling an object should fail")
+	}
+}
+
+func TestJSONInStruct(t *testing.T) {
+	payload := struct {
+		Items ArrayInterface
+		Sync  *SyncArray
+	}{New(1, 2), NewSync("a")}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, string(data), `{"Items":[1,2],"Sync":["a"]}`)
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	a := New(1, "a", New(2.5, New(order{ID: 1, Items: []string{"x"}})))
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(a); err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Array{}
+	if err := gob.NewDecoder(&buffer).Decode(decoded); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, decoded.Equal(a), true)
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	a := New(int8(1), uint(2), New("c"))
+	data, err := a.(*Array).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Array{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, decoded.Equal(a), true)
+}
+
+func TestTypeRegistry(t *testing.T) {
+	registry := NewTypeRegistry()
+	expect(t, registry.Register("order", order{}), nil)
+	expect(t, registry.Register("order pointer", &order{}), nil)
+	a := New(1, int64(2), order{ID: 3}, &order{ID: 4}, New(uint8(5), New(order{ID: 6})), []int{7})
+	data, err := registry.EncodeJSON(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := registry.DecodeJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, decoded.At(0), 1)
+	expect(t, decoded.At(1), int64(2))
+	expect(t, decoded.At(2), order{ID: 3})
+	expect(t, decoded.At(3).(*order).ID, 4)
+	expect(t, decoded.At(4), New(uint8(5), New(order{ID: 6})))
+	expect(t, decoded.At(5), New(7.0))
+
+	_, err = NewTypeRegistry().DecodeJSON(data)
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Error(err, "should be", ErrUnsupportedType)
+	}
+}
+
+func TestTypeRegistryErrors(t *testing.T) {
+	registry := NewTypeRegistry()
+	if err := registry.Register("int", ""); err == nil {
+		t.Error("registering a name twice should fail")
+	}
+	if err := registry.Register("array", order{}); err == nil {
+		t.Error("registering the reserved name should fail")
+	}
+	if err := registry.Register("nothing", nil); !errors.Is(err, ErrUnsupportedType) {
+		t.Error(err, "should be", ErrUnsupportedType)
+	}
+	expect(t, registry.Register("int", 0), nil)
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	var _ encoding.TextUnmarshaler = &Array{}
+	data, err := New(1, "a", New(true)).(*Array).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, string(data), `[1,"a",[true]]`)
+	decoded := &Array{}
+	if err := decoded.UnmarshalText(data); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, decoded, New(1.0, "a", New(true)))
+}
+
+func TestSyncArrayMarshalling(t *testing.T) {
+	s := NewSync(1, "a", New(order{ID: 2}))
+	for name, codec := range map[string]struct {
+		marshal   func(*SyncArray) ([]byte, error)
+		unmarshal func(*SyncArray, []byte) error
+	}{
+		"gob":    {(*SyncArray).GobEncode, (*SyncArray).GobDecode},
+		"binary": {(*SyncArray).MarshalBinary, (*SyncArray).UnmarshalBinary},
+	} {
+		data, err := codec.marshal(s)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		decoded := NewSync()
+		if err := codec.unmarshal(decoded, data); err != nil {
+			t.Fatal(name, err)
+		}
+		expect(t, decoded.Snapshot(), New(1, "a", New(order{ID: 2})))
+	}
+
+	data, err := NewSync(1, "b").MarshalText()
+	expect(t, err, nil)
+	expect(t, string(data), `[1,"b"]`)
+	decoded := NewSync(3)
+	expect(t, decoded.UnmarshalText(data), nil)
+	expect(t, decoded.Snapshot(), New(1.0, "b"))
+	if err := decoded.UnmarshalText([]byte("{")); err == nil {
+		t.Error("invalid text should fail")
+	}
+	expect(t, decoded.Length(), 2)
+
+	// a SyncArray field is encoded by gob through GobEncode
+	var buffer bytes.Buffer
+	type cache struct{ Values *SyncArray }
+	if err := gob.NewEncoder(&buffer).Encode(cache{NewSync(1, 2)}); err != nil {
+		t.Fatal(err)
+	}
+	var restored cache
+	if err := gob.NewDecoder(&buffer).Decode(&restored); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, restored.Values.Snapshot(), New(1, 2))
+}
@@ -0,0 +1,70 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// numberKind tells which field of a number holds its value
+type numberKind int
+
+const (
+	signedNumber numberKind = iota
+	unsignedNumber
+	floatNumber
+)
+
+// number is a value of any Go numeric kind
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// toNumber converts any integer or float value, including named types, to a number
+func toNumber(value interface{}) (number, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: signedNumber, i: v.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: unsignedNumber, u: v.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		return number{kind: floatNumber, f: v.Float()}, true
+	}
+	return number{}, false
+}
+
+// float returns the number as a float64
+func (n number) float() float64 {
+	switch n.kind {
+	case signedNumber:
+		return float64(n.i)
+	case unsignedNumber:
+		return float64(n.u)
+	}
+	return n.f
+}
+
+// compareNumbers compares 2 numbers without losing precision on integers
+func compareNumbers(x, y number) int {
+	switch {
+	case x.kind == signedNumber && y.kind == signedNumber:
+		return cmp.Compare(x.i, y.i)
+	case x.kind == unsignedNumber && y.kind == unsignedNumber:
+		return cmp.Compare(x.u, y.u)
+	case x.kind == signedNumber && y.kind == unsignedNumber:
+		if x.i < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(x.i), y.u)
+	case x.kind == unsignedNumber && y.kind == signedNumber:
+		return -compareNumbers(y, x)
+	}
+	return cmp.Compare(x.float(), y.float())
+}
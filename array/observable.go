@@ -0,0 +1,401 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+)
+
+// ChangeKind is the kind of a Change
+type ChangeKind int
+
+const (
+	// InsertChange is a value inserted at Index, moving the following values to the right
+	InsertChange ChangeKind = iota
+	// RemoveChange is a value removed from Index, moving the following values to the left
+	RemoveChange
+	// UpdateChange is a value replaced at Index
+	UpdateChange
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case InsertChange:
+		return "insert"
+	case RemoveChange:
+		return "remove"
+	case UpdateChange:
+		return "update"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change describes a single change of an ObservableArray.
+// OldValue is nil for insertions, NewValue is nil for removals.
+// Applying the changes of a notification in order to the array as it was
+// before gives the array as it is after
+type Change struct {
+	Kind     ChangeKind
+	Index    int
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// Apply applies the change to a, which is how a mirror of an ObservableArray
+// can be kept up to date
+func (c Change) Apply(a ArrayInterface) {
+	switch c.Kind {
+	case InsertChange:
+		a.Splice(c.Index, 0, c.NewValue)
+	case RemoveChange:
+		a.Splice(c.Index, 1)
+	case UpdateChange:
+		a.Fill(c.NewValue, c.Index, c.Index+1)
+	}
+}
+
+// Inverse returns the change undoing c
+func (c Change) Inverse() Change {
+	switch c.Kind {
+	case InsertChange:
+		return Change{Kind: RemoveChange, Index: c.Index, OldValue: c.NewValue}
+	case RemoveChange:
+		return Change{Kind: InsertChange, Index: c.Index, NewValue: c.OldValue}
+	}
+	return Change{Kind: c.Kind, Index: c.Index, OldValue: c.NewValue, NewValue: c.OldValue}
+}
+
+// arrayInterface is ArrayInterface under another name, so embedding it
+// doesn't hide the ArrayInterface method behind a field of the same name
+type arrayInterface = ArrayInterface
+
+// ObservableArray wraps an array and notifies subscribers of every change
+// made through its mutating methods.
+//
+//	todos := NewObservable(New("milk"))
+//	unsubscribe := todos.Subscribe(func(changes []Change) {
+//		for _, change := range changes {
+//			fmt.Println(change.Kind, change.Index, change.NewValue)
+//		}
+//	})
+//	defer unsubscribe()
+//	todos.Push("eggs") // prints: insert 1 eggs
+//
+// Each mutating call is notified as one slice of changes, Batch groups the changes
+// of several calls into a single notification. Changes made to the wrapped array
+// directly are not notified. An ObservableArray is not safe for concurrent use.
+type ObservableArray struct {
+	arrayInterface
+	subscribers []*subscriber
+	pending     []Change
+	batching    int
+	notifying   bool
+	// recorder, when set, is called with the changes of each mutating call
+	// as they happen, even inside a batch or a notification
+	recorder func([]Change)
+	// batchEnd, when set, is called at the end of the outermost batch,
+	// before its changes are notified
+	batchEnd func()
+}
+
+// subscriber is a function subscribed to an ObservableArray
+type subscriber struct {
+	notify func([]Change)
+	active bool
+}
+
+var _ ArrayInterface = (*ObservableArray)(nil)
+
+// NewObservable returns an observable array wrapping a, or a new array if a is nil
+func NewObservable(a ArrayInterface) *ObservableArray {
+	if a == nil {
+		a = New()
+	}
+	return &ObservableArray{arrayInterface: a}
+}
+
+// Unwrap returns the wrapped array
+func (o *ObservableArray) Unwrap() ArrayInterface {
+	return o.arrayInterface
+}
+
+// Subscribe calls notify with the changes after each mutating call, or once at the end
+// of a batch. Subscribers are called in subscription order, they may mutate the array:
+// the resulting changes are notified once every subscriber got the current ones.
+// The returned function unsubscribes notify, it can be called several times
+func (o *ObservableArray) Subscribe(notify func(changes []Change)) (unsubscribe func()) {
+	s := &subscriber{notify: notify, active: true}
+	o.subscribers = append(o.subscribers, s)
+	return func() {
+		if s.active {
+			s.active = false
+			o.subscribers = slices.DeleteFunc(o.subscribers, func(other *subscriber) bool { return other == s })
+		}
+	}
+}
+
+// Batch runs fn and notifies the changes it makes as a single slice once it returns,
+// even if it panics. Batches can be nested, changes are notified at the end of the outermost one
+func (o *ObservableArray) Batch(fn func()) {
+	o.batching++
+	defer func() {
+		o.batching--
+		if o.batching == 0 && o.batchEnd != nil {
+			o.batchEnd()
+		}
+		o.flush()
+	}()
+	fn()
+}
+
+// observed returns true if there are subscribers to notify or a recorder
+func (o *ObservableArray) observed() bool {
+	return len(o.subscribers) > 0 || o.recorder != nil
+}
+
+// emit records changes, then notifies them
+func (o *ObservableArray) emit(changes ...Change) {
+	if len(changes) == 0 {
+		return
+	}
+	if o.recorder != nil {
+		o.recorder(changes)
+	}
+	o.notify(changes)
+}
+
+// notify queues changes and notifies them unless a batch is running
+func (o *ObservableArray) notify(changes []Change) {
+	if len(changes) == 0 || len(o.subscribers) == 0 {
+		return
+	}
+	o.pending = append(o.pending, changes...)
+	o.flush()
+}
+
+// flush notifies the pending changes, unless a batch or a notification is running
+func (o *ObservableArray) flush() {
+	if o.batching > 0 || o.notifying {
+		return
+	}
+	o.notifying = true
+	defer func() { o.notifying = false }()
+	for len(o.pending) > 0 {
+		changes := o.pending
+		o.pending = nil
+		for _, s := range slices.Clone(o.subscribers) {
+			if s.active {
+				s.notify(changes)
+			}
+		}
+	}
+}
+
+// identical returns true if a and b are the same value: equal if they are comparable,
+// deeply equal otherwise
+func identical(a, b interface{}) bool {
+	return StrictEqual(a, b) || !reflect.ValueOf(a).Comparable() && reflect.DeepEqual(a, b)
+}
+
+// rewrite runs fn, which must not change the length of the array,
+// and emits an update for each value it replaced
+func (o *ObservableArray) rewrite(fn func()) {
+	if !o.observed() {
+		fn()
+		return
+	}
+	before := o.arrayInterface.ArrayInterface()
+	fn()
+	var changes []Change
+	for i, old := range before {
+		if value := o.arrayInterface.At(i); !identical(old, value) {
+			changes = append(changes, Change{Kind: UpdateChange, Index: i, OldValue: old, NewValue: value})
+		}
+	}
+	o.emit(changes...)
+}
+
+// Push put values at the end of array
+func (o *ObservableArray) Push(values ...interface{}) int {
+	length := o.arrayInterface.Length()
+	n := o.arrayInterface.Push(values...)
+	changes := make([]Change, len(values))
+	for i, value := range values {
+		changes[i] = Change{Kind: InsertChange, Index: length + i, NewValue: value}
+	}
+	o.emit(changes...)
+	return n
+}
+
+// Pop remove the last value of the array
+func (o *ObservableArray) Pop() interface{} {
+	value, _ := o.TryPop()
+	return value
+}
+
+// TryPop remove the last value of the array, ok is false if the array is empty
+func (o *ObservableArray) TryPop() (value interface{}, ok bool) {
+	index := o.arrayInterface.Length() - 1
+	if value, ok = o.arrayInterface.TryPop(); ok {
+		o.emit(Change{Kind: RemoveChange, Index: index, OldValue: value})
+	}
+	return value, ok
+}
+
+// PopE remove the last value of the array, returns ErrEmpty if the array is empty
+func (o *ObservableArray) PopE() (interface{}, error) {
+	if value, ok := o.TryPop(); ok {
+		return value, nil
+	}
+	return nil, ErrEmpty
+}
+
+// Shift removes the first element of the array and returns it
+func (o *ObservableArray) Shift() interface{} {
+	value, _ := o.TryShift()
+	return value
+}
+
+// TryShift removes the first element of the array and returns it,
+// ok is false if the array is empty
+func (o *ObservableArray) TryShift() (value interface{}, ok bool) {
+	if value, ok = o.arrayInterface.TryShift(); ok {
+		o.emit(Change{Kind: RemoveChange, Index: 0, OldValue: value})
+	}
+	return value, ok
+}
+
+// ShiftE removes the first element of the array and returns it,
+// returns ErrEmpty if the array is empty
+func (o *ObservableArray) ShiftE() (interface{}, error) {
+	if value, ok := o.TryShift(); ok {
+		return value, nil
+	}
+	return nil, ErrEmpty
+}
+
+// Unshift add elements at index 0 and returns the number of added elements,
+// each value is notified as an insertion at index 0
+func (o *ObservableArray) Unshift(values ...interface{}) int {
+	n := o.arrayInterface.Unshift(values...)
+	changes := make([]Change, len(values))
+	for i, value := range values {
+		changes[i] = Change{Kind: InsertChange, Index: 0, NewValue: value}
+	}
+	o.emit(changes...)
+	return n
+}
+
+// Splice remove elements from the array at a given index and optionally insert new elements,
+// notified as removals followed by insertions
+func (o *ObservableArray) Splice(start int, deleteCount int, items ...interface{}) ArrayInterface {
+	start = relativeIndex(start, o.arrayInterface.Length())
+	removed := o.arrayInterface.Splice(start, deleteCount, items...)
+	changes := make([]Change, 0, removed.Length()+len(items))
+	for value := range removed.Values() {
+		changes = append(changes, Change{Kind: RemoveChange, Index: start, OldValue: value})
+	}
+	for i, value := range items {
+		changes = append(changes, Change{Kind: InsertChange, Index: start + i, NewValue: value})
+	}
+	o.emit(changes...)
+	return removed
+}
+
+// Clear removes all the elements, notified as removals from the end
+func (o *ObservableArray) Clear() {
+	o.Truncate(0)
+}
+
+// Truncate removes the elements from index n, notified as removals from the end
+func (o *ObservableArray) Truncate(n int) {
+	var removed []interface{}
+	if o.observed() && n < o.arrayInterface.Length() {
+		removed = o.arrayInterface.Slice(max(n, 0)).ArrayInterface()
+	}
+	o.arrayInterface.Truncate(n)
+	changes := make([]Change, len(removed))
+	for i := range removed {
+		index := len(removed) - 1 - i
+		changes[i] = Change{Kind: RemoveChange, Index: max(n, 0) + index, OldValue: removed[index]}
+	}
+	o.emit(changes...)
+}
+
+// InsertSorted inserts value so the array stays sorted and returns its index
+func (o *ObservableArray) InsertSorted(value interface{}, compare Comparator) int {
+	index := o.arrayInterface.InsertSorted(value, compare)
+	o.emit(Change{Kind: InsertChange, Index: index, NewValue: value})
+	return index
+}
+
+// RemoveSorted removes the first element equivalent to value from the sorted array
+// and returns its index, or -1 if there is none
+func (o *ObservableArray) RemoveSorted(value interface{}, compare Comparator) int {
+	index, found := o.arrayInterface.BinarySearch(value, compare)
+	if !found {
+		return -1
+	}
+	old := o.arrayInterface.At(index)
+	o.arrayInterface.Splice(index, 1)
+	o.emit(Change{Kind: RemoveChange, Index: index, OldValue: old})
+	return index
+}
+
+// ReverseInPlace reverse the order of the elements of the array and returns it,
+// notified as updates
+func (o *ObservableArray) ReverseInPlace() ArrayInterface {
+	o.rewrite(func() { o.arrayInterface.ReverseInPlace() })
+	return o
+}
+
+// ShuffleInPlace shuffles the elements of the array and returns it, notified as updates
+func (o *ObservableArray) ShuffleInPlace(source rand.Source) ArrayInterface {
+	o.rewrite(func() { o.arrayInterface.ShuffleInPlace(source) })
+	return o
+}
+
+// SortInPlace sorts the array and returns it, notified as updates
+func (o *ObservableArray) SortInPlace(compare Comparator) ArrayInterface {
+	o.rewrite(func() { o.arrayInterface.SortInPlace(compare) })
+	return o
+}
+
+// SortStable sorts the array keeping the order of equivalent values and returns it,
+// notified as updates
+func (o *ObservableArray) SortStable(compare Comparator) ArrayInterface {
+	o.rewrite(func() { o.arrayInterface.SortStable(compare) })
+	return o
+}
+
+// Fill fills the array with value and returns it, notified as updates
+func (o *ObservableArray) Fill(value interface{}, startAndEnd ...int) ArrayInterface {
+	o.rewrite(func() { o.arrayInterface.Fill(value, startAndEnd...) })
+	return o
+}
+
+// CopyWithin copies part of the array within itself and returns it, notified as updates
+func (o *ObservableArray) CopyWithin(target int, startAndEnd ...int) ArrayInterface {
+	o.rewrite(func() { o.arrayInterface.CopyWithin(target, startAndEnd...) })
+	return o
+}
+
+// Format formats the wrapped array
+func (o *ObservableArray) Format(f fmt.State, verb rune) {
+	if formatter, ok := o.arrayInterface.(fmt.Formatter); ok {
+		formatter.Format(f, verb)
+		return
+	}
+	fmt.Fprint(f, o.arrayInterface.String())
+}
+
+// MarshalJSON encodes the wrapped array as a JSON array
+func (o *ObservableArray) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.arrayInterface)
+}
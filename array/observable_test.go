@@ -0,0 +1,156 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestObservableArrayChanges(t *testing.T) {
+	o := NewObservable(New(1, 2, 3))
+	var notifications [][]Change
+	o.Subscribe(func(changes []Change) { notifications = append(notifications, changes) })
+	type fixture struct {
+		name     string
+		mutate   func()
+		expected []Change
+	}
+	for _, fix := range []fixture{
+		{"Push", func() { o.Push(4, 5) }, []Change{{InsertChange, 3, nil, 4}, {InsertChange, 4, nil, 5}}},
+		{"Pop", func() { expect(t, o.Pop(), 5) }, []Change{{RemoveChange, 4, 5, nil}}},
+		{"Shift", func() { expect(t, o.Shift(), 1) }, []Change{{RemoveChange, 0, 1, nil}}},
+		{"Unshift", func() { o.Unshift(0, 1) }, []Change{{InsertChange, 0, nil, 0}, {InsertChange, 0, nil, 1}}},
+		{"Splice", func() { o.Splice(-3, 2, "a") }, []Change{{RemoveChange, 2, 2, nil}, {RemoveChange, 2, 3, nil}, {InsertChange, 2, nil, "a"}}},
+		{"SortInPlace", func() { o.SortInPlace(CompareNatural) }, []Change{{UpdateChange, 0, 1, 0}, {UpdateChange, 1, 0, 1}, {UpdateChange, 2, "a", 4}, {UpdateChange, 3, 4, "a"}}},
+		{"Fill", func() { o.Fill(1, 0, 2) }, []Change{{UpdateChange, 0, 0, 1}}},
+		{"Truncate", func() { o.Truncate(2) }, []Change{{RemoveChange, 3, "a", nil}, {RemoveChange, 2, 4, nil}}},
+	} {
+		notifications = nil
+		fix.mutate()
+		if len(notifications) != 1 {
+			t.Fatal(fix.name, "should notify once, got", notifications)
+		}
+		expect(t, notifications[0], fix.expected)
+	}
+	expectArray(t, o, 1, 1)
+}
+
+func TestObservableArrayEmptyRemovalsDontNotify(t *testing.T) {
+	o := NewObservable(New(1))
+	notifications := 0
+	o.Subscribe(func(changes []Change) { notifications++ })
+	o.Pop()
+	expect(t, notifications, 1)
+	expect(t, o.Pop(), nil)
+	_, ok := o.TryShift()
+	expect(t, ok, false)
+	_, ok = o.TryPop()
+	expect(t, ok, false)
+	if _, err := o.ShiftE(); err != ErrEmpty {
+		t.Error(err, "should be", ErrEmpty)
+	}
+	expect(t, o.Shift(), nil)
+	o.Truncate(0)
+	o.Clear()
+	o.Splice(0, 1)
+	expect(t, notifications, 1)
+}
+
+func TestObservableArrayChangesReplay(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	o := NewObservable(New(1, 2, 3))
+	mirror := New(1, 2, 3)
+	o.Subscribe(func(changes []Change) {
+		for _, change := range changes {
+			change.Apply(mirror)
+		}
+	})
+	operations := []func(){
+		func() { o.Push(r.IntN(100), r.IntN(100)) },
+		func() { o.Pop() },
+		func() { o.TryShift() },
+		func() { o.Unshift(r.IntN(100), r.IntN(100)) },
+		func() { o.Splice(r.IntN(10)-5, r.IntN(4), r.IntN(100)) },
+		func() { o.SortStable(CompareNumbers) },
+		func() { o.ReverseInPlace() },
+		func() { o.ShuffleInPlace(r) },
+		func() { o.CopyWithin(r.IntN(5), r.IntN(5)) },
+		func() { o.Fill(r.IntN(100), r.IntN(5), r.IntN(10)) },
+		func() { o.InsertSorted(r.IntN(100), CompareNumbers) },
+		func() { o.RemoveSorted(r.IntN(100), CompareNumbers) },
+		func() { o.Truncate(r.IntN(20)) },
+		func() { o.PopE() },
+	}
+	for i := 0; i < 2000; i++ {
+		operations[r.IntN(len(operations))]()
+		if !mirror.Equal(o) {
+			t.Fatal("mirror", mirror, "should be", o, "after", i, "operations")
+		}
+	}
+	o.Clear()
+	expect(t, mirror.Length(), 0)
+}
+
+func TestObservableArrayBatch(t *testing.T) {
+	o := NewObservable(nil)
+	var notifications [][]Change
+	o.Subscribe(func(changes []Change) { notifications = append(notifications, changes) })
+	o.Batch(func() {
+		o.Push(1)
+		o.Batch(func() { o.Push(2) })
+		o.Shift()
+		expect(t, len(notifications), 0)
+	})
+	expect(t, notifications, [][]Change{{{InsertChange, 0, nil, 1}, {InsertChange, 1, nil, 2}, {RemoveChange, 0, 1, nil}}})
+
+	notifications = nil
+	func() {
+		defer func() { recover() }()
+		o.Batch(func() {
+			o.Push(3)
+			panic("validation failed")
+		})
+	}()
+	expect(t, len(notifications), 1)
+}
+
+func TestObservableArraySubscribers(t *testing.T) {
+	o := NewObservable(New())
+	var log []string
+	unsubscribeFirst := o.Subscribe(func(changes []Change) {
+		log = append(log, fmt.Sprint("first ", changes[0].Kind, " ", changes[0].NewValue))
+		// mutations made while notifying are notified after the current changes
+		if changes[0].NewValue == 1 {
+			o.Push(2)
+		}
+	})
+	o.Subscribe(func(changes []Change) {
+		log = append(log, fmt.Sprint("second ", changes[0].Kind, " ", changes[0].NewValue))
+	})
+	o.Push(1)
+	expect(t, log, []string{"first insert 1", "second insert 1", "first insert 2", "second insert 2"})
+
+	log = nil
+	unsubscribeFirst()
+	unsubscribeFirst()
+	o.Pop()
+	expect(t, log, []string{"second remove <nil>"})
+}
+
+func TestObservableArrayIsAnArray(t *testing.T) {
+	o := NewObservable(NewSync(3, 1, 2))
+	expectSame(t, o.SortInPlace(CompareNumbers), ArrayInterface(o))
+	expect(t, o.Map(func(v interface{}, i int) interface{} { return v.(int) * 2 }), New(2, 4, 6))
+	expect(t, fmt.Sprint(o), "ArrayInterface[1, 2, 3]")
+	data, err := json.Marshal(o)
+	expect(t, err, nil)
+	expect(t, string(data), "[1,2,3]")
+	expect(t, ChangeKind(7).String(), "ChangeKind(7)")
+	_, isSync := o.Unwrap().(*SyncArray)
+	expect(t, isSync, true)
+}
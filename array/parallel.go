@@ -0,0 +1,181 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelForEach executes callback on each element of the array using at most limit
+// goroutines, or GOMAXPROCS goroutines if limit is less than 1.
+//
+// It stops at the first error or when ctx is done. Errors and panics from callback
+// are returned as an *ElementError holding the failing index, panics are wrapped
+// into a *PanicError. The context passed to callback is canceled on the first error.
+func ParallelForEach(ctx context.Context, a ArrayInterface, limit int, callback func(ctx context.Context, value interface{}, i int) error) error {
+	values := a.ArrayInterface()
+	return parallel(ctx, len(values), limit, func(ctx context.Context, i int) error {
+		return callback(ctx, values[i], i)
+	})
+}
+
+// ParallelMap maps the elements of the array in parallel like ParallelForEach,
+// the results keep the order of the array
+func ParallelMap(ctx context.Context, a ArrayInterface, limit int, callback func(ctx context.Context, value interface{}, i int) (interface{}, error)) (ArrayInterface, error) {
+	values := a.ArrayInterface()
+	results := make([]interface{}, len(values))
+	err := parallel(ctx, len(values), limit, func(ctx context.Context, i int) (err error) {
+		results[i], err = callback(ctx, values[i], i)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Array{buffer: results, length: len(results)}, nil
+}
+
+// ParallelFilter filters the elements of the array in parallel like ParallelForEach,
+// the results keep the order of the array
+func ParallelFilter(ctx context.Context, a ArrayInterface, limit int, predicate func(ctx context.Context, value interface{}, i int) (bool, error)) (ArrayInterface, error) {
+	values := a.ArrayInterface()
+	keep := make([]bool, len(values))
+	err := parallel(ctx, len(values), limit, func(ctx context.Context, i int) (err error) {
+		keep[i], err = predicate(ctx, values[i], i)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &Array{}
+	for i, value := range values {
+		if keep[i] {
+			result.Push(value)
+		}
+	}
+	return result, nil
+}
+
+// ParallelReduce folds the array into a single value using at most limit goroutines.
+//
+// The array is split into consecutive chunks, each reduced from identity, then the
+// partial results are reduced in order. reducer must be associative and identity
+// must be its neutral element, such as 0 for a sum.
+// Errors are handled like ParallelForEach, the index of an *ElementError is the index
+// of the first element of the failing chunk. Errors combining partial results
+// are returned as is, panics as a *PanicError. When ctx is done, ctx.Err() is returned.
+func ParallelReduce(ctx context.Context, a ArrayInterface, limit int, reducer func(result, value interface{}) (interface{}, error), identity interface{}) (interface{}, error) {
+	values := a.ArrayInterface()
+	workers := workerCount(len(values), limit)
+	if workers == 0 {
+		return identity, ctx.Err()
+	}
+	size := (len(values) + workers - 1) / workers
+	partials := make([]interface{}, (len(values)+size-1)/size)
+	err := parallel(ctx, len(partials), workers, func(ctx context.Context, chunk int) (err error) {
+		result := identity
+		for i := chunk * size; i < len(values) && i < (chunk+1)*size; i++ {
+			if ctx.Err() != nil {
+				// parallel returns ctx.Err() once the workers stop
+				return nil
+			}
+			if result, err = reducer(result, values[i]); err != nil {
+				return err
+			}
+		}
+		partials[chunk] = result
+		return nil
+	})
+	if err != nil {
+		if elementError, ok := err.(*ElementError); ok {
+			elementError.Index *= size
+		}
+		return nil, err
+	}
+	result := identity
+	for _, partial := range partials {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if result, err = combine(reducer, result, partial); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// combine calls reducer, turning its panic into a *PanicError
+func combine(reducer func(result, value interface{}) (interface{}, error), result, value interface{}) (combined interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return reducer(result, value)
+}
+
+// workerCount returns the number of goroutines used for n elements
+func workerCount(n, limit int) int {
+	if limit < 1 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if limit > n {
+		return n
+	}
+	return limit
+}
+
+// parallel calls fn for each index in [0, n) using at most limit goroutines
+// and returns the first error
+func parallel(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		next     atomic.Int64
+	)
+	for w := workerCount(n, limit); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := protect(ctx, i, fn); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// protect calls fn, turning its error or panic into an *ElementError
+func protect(ctx context.Context, i int, fn func(ctx context.Context, i int) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &ElementError{Index: i, Err: &PanicError{Value: r, Stack: debug.Stack()}}
+		}
+	}()
+	if err := fn(ctx, i); err != nil {
+		return &ElementError{Index: i, Err: err}
+	}
+	return nil
+}
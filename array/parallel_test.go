@@ -0,0 +1,192 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func numbers(n int) ArrayInterface {
+	a := New()
+	for i := 0; i < n; i++ {
+		a.Push(i)
+	}
+	return a
+}
+
+func TestParallelMapKeepsOrder(t *testing.T) {
+	result, err := ParallelMap(context.Background(), numbers(1000), 8, func(ctx context.Context, v interface{}, i int) (interface{}, error) {
+		return v.(int) * 2, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, result.Length(), 1000)
+	for i := 0; i < 1000; i++ {
+		expect(t, result.At(i), i*2)
+	}
+}
+
+func TestParallelRespectsLimit(t *testing.T) {
+	var running, maxRunning atomic.Int64
+	err := ParallelForEach(context.Background(), numbers(100), 3, func(ctx context.Context, v interface{}, i int) error {
+		n := running.Add(1)
+		for {
+			max := maxRunning.Load()
+			if n <= max || maxRunning.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+	expect(t, err, nil)
+	if maxRunning.Load() > 3 {
+		t.Error(maxRunning.Load(), "goroutines should be at most 3")
+	}
+}
+
+func TestParallelFilter(t *testing.T) {
+	result, err := ParallelFilter(context.Background(), numbers(10), 4, func(ctx context.Context, v interface{}, i int) (bool, error) {
+		return v.(int)%3 == 0, nil
+	})
+	expect(t, err, nil)
+	expectArray(t, result, 0, 3, 6, 9)
+}
+
+func TestParallelStopsOnFirstError(t *testing.T) {
+	failure := errors.New("failure")
+	var calls atomic.Int64
+	_, err := ParallelMap(context.Background(), numbers(10000), 2, func(ctx context.Context, v interface{}, i int) (interface{}, error) {
+		calls.Add(1)
+		if i == 10 {
+			return nil, failure
+		}
+		return v, nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatal(err, "should be", failure)
+	}
+	var elementError *ElementError
+	if !errors.As(err, &elementError) {
+		t.Fatal(err, "should be an *ElementError")
+	}
+	expect(t, elementError.Index, 10)
+	if calls.Load() == 10000 {
+		t.Error("ParallelMap should stop after the first error")
+	}
+}
+
+func TestParallelRecoversPanics(t *testing.T) {
+	err := ParallelForEach(context.Background(), numbers(10), 4, func(ctx context.Context, v interface{}, i int) error {
+		if i == 5 {
+			panic("boom")
+		}
+		return nil
+	})
+	var panicError *PanicError
+	if !errors.As(err, &panicError) {
+		t.Fatal(err, "should be a *PanicError")
+	}
+	expect(t, panicError.Value, "boom")
+	var elementError *ElementError
+	errors.As(err, &elementError)
+	expect(t, elementError.Index, 5)
+}
+
+func TestParallelCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int64
+	err := ParallelForEach(ctx, numbers(10000), 2, func(ctx context.Context, v interface{}, i int) error {
+		if calls.Add(1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal(err, "should be", context.Canceled)
+	}
+	if calls.Load() == 10000 {
+		t.Error("ParallelForEach should stop when the context is canceled")
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	sum := func(result, value interface{}) (interface{}, error) {
+		return result.(int) + value.(int), nil
+	}
+	for _, limit := range []int{0, 1, 3, 7, 200} {
+		result, err := ParallelReduce(context.Background(), numbers(101), limit, sum, 0)
+		expect(t, err, nil)
+		expect(t, result, 5050)
+	}
+	result, err := ParallelReduce(context.Background(), New(), 4, sum, 0)
+	expect(t, result, 0)
+	expect(t, err, nil)
+
+	concat := func(result, value interface{}) (interface{}, error) {
+		return result.(string) + value.(string), nil
+	}
+	result, _ = ParallelReduce(context.Background(), New("a", "b", "c", "d", "e"), 2, concat, "")
+	expect(t, result, "abcde")
+
+	failure := errors.New("failure")
+	_, err = ParallelReduce(context.Background(), numbers(10), 2, func(result, value interface{}) (interface{}, error) {
+		if value.(int) == 7 {
+			return nil, failure
+		}
+		return result, nil
+	}, 0)
+	var elementError *ElementError
+	if !errors.As(err, &elementError) {
+		t.Fatal(err, "should be an *ElementError")
+	}
+	expect(t, elementError.Index, 5)
+}
+
+func TestParallelReduceCombineStep(t *testing.T) {
+	// elements are at most 9, larger values are partial results being combined
+	_, err := ParallelReduce(context.Background(), numbers(10), 2, func(result, value interface{}) (interface{}, error) {
+		if value.(int) > 9 {
+			panic("combine failure")
+		}
+		return result.(int) + value.(int), nil
+	}, 0)
+	var panicError *PanicError
+	if !errors.As(err, &panicError) || panicError.Value != "combine failure" {
+		t.Fatal(err, "should be a *PanicError")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = ParallelReduce(ctx, numbers(10), 2, func(result, value interface{}) (interface{}, error) {
+		if value.(int) > 9 {
+			cancel()
+		}
+		return result.(int) + value.(int), nil
+	}, 0)
+	expect(t, err, context.Canceled)
+}
+
+func TestParallelReduceCanceledInChunk(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := ParallelReduce(ctx, numbers(10), 1, func(result, value interface{}) (interface{}, error) {
+		if value.(int) == 3 {
+			cancel()
+		}
+		return result.(int) + value.(int), nil
+	}, 0)
+	var elementError *ElementError
+	if errors.As(err, &elementError) {
+		t.Error(err, "should not be an *ElementError")
+	}
+	expect(t, err, context.Canceled)
+}
@@ -0,0 +1,340 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"iter"
+	"slices"
+)
+
+// Query is a lazy, chainable pipeline over the values of an array.
+//
+// Operations such as Where, Select or Take only describe the pipeline, nothing
+// runs until a terminal method such as ToArray, First or Count is called.
+// Values then flow one at a time through the whole pipeline in a single pass,
+// which stops as soon as the result is known.
+//
+//	first10 := NewQuery(orders).
+//		Where(func(v interface{}, i int) bool { return v.(*Order).Paid }).
+//		Select(func(v interface{}, i int) interface{} { return v.(*Order).Total }).
+//		Take(10).
+//		ToArray()
+//
+// Callbacks receive the value and its index in the sequence they are applied to.
+type Query struct {
+	seq iter.Seq[interface{}]
+}
+
+// Group is a key and the values sharing it, produced by Query.GroupBy
+type Group struct {
+	Key    interface{}
+	Values ArrayInterface
+}
+
+// NewQuery returns a query over the values of an array
+func NewQuery(a ArrayInterface) Query {
+	return Query{seq: a.Values()}
+}
+
+// QueryFrom returns a query over the values of an iterator
+func QueryFrom(seq iter.Seq[interface{}]) Query {
+	return Query{seq: seq}
+}
+
+// Values returns an iterator over the values of the query
+func (q Query) Values() iter.Seq[interface{}] {
+	return q.seq
+}
+
+// Where keeps the values satisfying predicate
+func (q Query) Where(predicate func(interface{}, int) bool) Query {
+	return Query{func(yield func(interface{}) bool) {
+		i := 0
+		for value := range q.seq {
+			if predicate(value, i) && !yield(value) {
+				return
+			}
+			i++
+		}
+	}}
+}
+
+// Select replaces each value with the result of selector
+func (q Query) Select(selector func(interface{}, int) interface{}) Query {
+	return Query{func(yield func(interface{}) bool) {
+		i := 0
+		for value := range q.seq {
+			if !yield(selector(value, i)) {
+				return
+			}
+			i++
+		}
+	}}
+}
+
+// Take keeps the first n values
+func (q Query) Take(n int) Query {
+	return Query{func(yield func(interface{}) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for value := range q.seq {
+			if !yield(value) {
+				return
+			}
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}}
+}
+
+// Skip drops the first n values
+func (q Query) Skip(n int) Query {
+	return Query{func(yield func(interface{}) bool) {
+		i := 0
+		for value := range q.seq {
+			if i >= n && !yield(value) {
+				return
+			}
+			i++
+		}
+	}}
+}
+
+// TakeWhile keeps values until predicate is not satisfied
+func (q Query) TakeWhile(predicate func(interface{}, int) bool) Query {
+	return Query{func(yield func(interface{}) bool) {
+		i := 0
+		for value := range q.seq {
+			if !predicate(value, i) || !yield(value) {
+				return
+			}
+			i++
+		}
+	}}
+}
+
+// SkipWhile drops values until predicate is not satisfied
+func (q Query) SkipWhile(predicate func(interface{}, int) bool) Query {
+	return Query{func(yield func(interface{}) bool) {
+		i := 0
+		skipping := true
+		for value := range q.seq {
+			skipping = skipping && predicate(value, i)
+			if !skipping && !yield(value) {
+				return
+			}
+			i++
+		}
+	}}
+}
+
+// Distinct drops values already seen. Hashable values are compared with ==,
+// others with DeepEqual
+func (q Query) Distinct() Query {
+	return Query{func(yield func(interface{}) bool) {
+		seen := newValueSet(DeepEqual)
+		for value := range q.seq {
+			if seen.add(value) && !yield(value) {
+				return
+			}
+		}
+	}}
+}
+
+// GroupBy groups values by the result of key, yielding a Group per key
+// in the order keys are first seen. Keys are compared like KeyMap does.
+// GroupBy needs to read all the values before yielding the first group
+func (q Query) GroupBy(key func(interface{}) interface{}) Query {
+	return Query{func(yield func(interface{}) bool) {
+		for k, values := range GroupBy(q.ToArray(), key).All() {
+			if !yield(Group{Key: k, Values: values}) {
+				return
+			}
+		}
+	}}
+}
+
+// OrderBy sorts values with compare, keeping the order of equivalent values.
+// OrderBy needs to read all the values before yielding the first one
+func (q Query) OrderBy(compare Comparator) OrderedQuery {
+	return OrderedQuery{source: q, comparators: []Comparator{compare}}.build()
+}
+
+// Zip pairs values with the values of other, yielding New(value, otherValue)
+// until one of them is exhausted
+func (q Query) Zip(other ArrayInterface) Query {
+	return Query{func(yield func(interface{}) bool) {
+		next, stop := iter.Pull(other.Values())
+		defer stop()
+		for value := range q.seq {
+			otherValue, ok := next()
+			if !ok || !yield(New(value, otherValue)) {
+				return
+			}
+		}
+	}}
+}
+
+// Chunk groups consecutive values into arrays of size values, the last one may be shorter
+//
+// CAN PANIC if size is less than 1
+func (q Query) Chunk(size int) Query {
+	if size < 1 {
+		panic("array: chunk size must be positive")
+	}
+	return Query{func(yield func(interface{}) bool) {
+		chunk := &Array{}
+		for value := range q.seq {
+			chunk.Push(value)
+			if chunk.length == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = &Array{}
+			}
+		}
+		if chunk.length > 0 {
+			yield(chunk)
+		}
+	}}
+}
+
+// Window yields every run of size consecutive values as a new array
+//
+// CAN PANIC if size is less than 1
+func (q Query) Window(size int) Query {
+	if size < 1 {
+		panic("array: window size must be positive")
+	}
+	return Query{func(yield func(interface{}) bool) {
+		window := &Array{}
+		for value := range q.seq {
+			window.Push(value)
+			if window.length > size {
+				window.Shift()
+			}
+			if window.length == size && !yield(window.slice(0, size)) {
+				return
+			}
+		}
+	}}
+}
+
+// ToArray runs the query and returns its values
+func (q Query) ToArray() ArrayInterface {
+	result := &Array{}
+	for value := range q.seq {
+		result.Push(value)
+	}
+	return result
+}
+
+// First returns the first value, ok is false if the query is empty
+func (q Query) First() (value interface{}, ok bool) {
+	for value := range q.seq {
+		return value, true
+	}
+	return nil, false
+}
+
+// Count returns the number of values
+func (q Query) Count() int {
+	count := 0
+	for range q.seq {
+		count++
+	}
+	return count
+}
+
+// Sum returns the sum of the values, which can be of any numeric kind.
+// Returns a *NumericError for the first value which is not a number
+func (q Query) Sum() (float64, error) {
+	sum, _, err := q.sum()
+	return sum, err
+}
+
+// Average returns the mean of the values, which can be of any numeric kind.
+// Returns ErrEmpty if the query is empty
+// and a *NumericError for the first value which is not a number
+func (q Query) Average() (float64, error) {
+	sum, count, err := q.sum()
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, ErrEmpty
+	}
+	return sum / float64(count), nil
+}
+
+// sum returns the sum and number of the values
+func (q Query) sum() (sum float64, count int, err error) {
+	for value := range q.seq {
+		n, ok := toNumber(value)
+		if !ok {
+			return 0, count, &NumericError{Index: count, Value: value}
+		}
+		sum += n.float()
+		count++
+	}
+	return sum, count, nil
+}
+
+// Min returns the smallest value according to compare, ok is false if the query is empty
+func (q Query) Min(compare Comparator) (value interface{}, ok bool) {
+	for v := range q.seq {
+		if !ok || compare(v, value) < 0 {
+			value, ok = v, true
+		}
+	}
+	return value, ok
+}
+
+// Max returns the largest value according to compare, ok is false if the query is empty
+func (q Query) Max(compare Comparator) (value interface{}, ok bool) {
+	for v := range q.seq {
+		if !ok || compare(v, value) > 0 {
+			value, ok = v, true
+		}
+	}
+	return value, ok
+}
+
+// OrderedQuery is a Query sorted with OrderBy, ThenBy adds secondary sort keys
+type OrderedQuery struct {
+	Query
+	source      Query
+	comparators []Comparator
+}
+
+// ThenBy sorts values that are equivalent for the previous comparators with compare
+func (q OrderedQuery) ThenBy(compare Comparator) OrderedQuery {
+	return OrderedQuery{source: q.source, comparators: append(slices.Clip(q.comparators), compare)}.build()
+}
+
+// build sets the sequence of the query
+func (q OrderedQuery) build() OrderedQuery {
+	q.Query = Query{func(yield func(interface{}) bool) {
+		values := slices.Collect(q.source.seq)
+		slices.SortStableFunc(values, func(a, b interface{}) int {
+			for _, compare := range q.comparators {
+				if result := compare(a, b); result != 0 {
+					return result
+				}
+			}
+			return 0
+		})
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}}
+	return q
+}
@@ -0,0 +1,141 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"testing"
+)
+
+func isEvenValue(v interface{}, i int) bool {
+	return v.(int)%2 == 0
+}
+
+func TestQueryIsLazy(t *testing.T) {
+	visited := 0
+	q := NewQuery(New(1, 2, 3, 4, 5, 6, 7, 8)).
+		Select(func(v interface{}, i int) interface{} {
+			visited++
+			return v
+		}).
+		Where(isEvenValue).
+		Take(2)
+	expect(t, visited, 0)
+	expectArray(t, q.ToArray(), 2, 4)
+	expect(t, visited, 4)
+}
+
+func TestQueryOperations(t *testing.T) {
+	source := New(1, 2, 3, 4, 5)
+	type fixture struct {
+		name     string
+		query    Query
+		expected []interface{}
+	}
+	for _, fix := range []fixture{
+		{"Where", NewQuery(source).Where(isEvenValue), []interface{}{2, 4}},
+		{"Where index", NewQuery(source).Where(func(v interface{}, i int) bool { return i > 2 }), []interface{}{4, 5}},
+		{"Select", NewQuery(source).Select(func(v interface{}, i int) interface{} { return v.(int) * i }), []interface{}{0, 2, 6, 12, 20}},
+		{"Take", NewQuery(source).Take(2), []interface{}{1, 2}},
+		{"Take 0", NewQuery(source).Take(0), []interface{}{}},
+		{"Skip", NewQuery(source).Skip(3), []interface{}{4, 5}},
+		{"TakeWhile", NewQuery(source).TakeWhile(func(v interface{}, i int) bool { return v.(int) < 3 }), []interface{}{1, 2}},
+		{"SkipWhile", NewQuery(New(1, 2, 3, 1)).SkipWhile(func(v interface{}, i int) bool { return v.(int) < 3 }), []interface{}{3, 1}},
+		{"Distinct", NewQuery(New(1, 2, 1, "1", 2)).Distinct(), []interface{}{1, 2, "1"}},
+		{"OrderBy", NewQuery(New(3, 1, 2)).OrderBy(CompareNumbers).Query, []interface{}{1, 2, 3}},
+		{"Skip Take", NewQuery(source).Skip(1).Take(2), []interface{}{2, 3}},
+	} {
+		actual := fix.query.ToArray()
+		if !actual.Equal(New(fix.expected...)) {
+			t.Error(fix.name, actual, "should be", fix.expected)
+		}
+	}
+}
+
+func TestQueryDistinctUnhashable(t *testing.T) {
+	result := NewQuery(New([]int{1}, []int{1}, []int{2})).Distinct().ToArray()
+	expect(t, result.Length(), 2)
+}
+
+func TestQueryGroupBy(t *testing.T) {
+	groups := NewQuery(New(1, 2, 3, 4, 5)).GroupBy(func(v interface{}) interface{} {
+		return v.(int) % 2
+	}).ToArray()
+	expect(t, groups.Length(), 2)
+	expect(t, groups.At(0).(Group).Key, 1)
+	expectArray(t, groups.At(0).(Group).Values, 1, 3, 5)
+	expect(t, groups.At(1).(Group).Key, 0)
+	expectArray(t, groups.At(1).(Group).Values, 2, 4)
+
+	groups = NewQuery(New(1, 2)).GroupBy(func(v interface{}) interface{} {
+		return []int{v.(int) % 1}
+	}).ToArray()
+	expect(t, groups.Length(), 1)
+}
+
+func TestQueryOrderByThenBy(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	byAge := func(a, b interface{}) int { return CompareNumbers(a.(person).age, b.(person).age) }
+	byName := func(a, b interface{}) int { return CompareStrings(a.(person).name, b.(person).name) }
+	people := New(person{"b", 30}, person{"c", 20}, person{"a", 30})
+	ordered := NewQuery(people).OrderBy(byAge)
+	sorted := ordered.ThenBy(byName).ToArray()
+	expect(t, sorted.At(0).(person).name, "c")
+	expect(t, sorted.At(1).(person).name, "a")
+	expect(t, sorted.At(2).(person).name, "b")
+	// ThenBy doesn't change the query it is called on
+	expect(t, ordered.ToArray().At(1).(person).name, "b")
+	expect(t, ordered.ThenBy(Descending(byName)).Take(2).ToArray().At(1).(person).name, "b")
+}
+
+func TestQueryZipChunkWindow(t *testing.T) {
+	zipped := NewQuery(New(1, 2, 3)).Zip(New("a", "b")).ToArray()
+	expect(t, zipped.Length(), 2)
+	expectArray(t, zipped.At(1).(ArrayInterface), 2, "b")
+
+	chunks := NewQuery(New(1, 2, 3, 4, 5)).Chunk(2).ToArray()
+	expect(t, chunks.Length(), 3)
+	expectArray(t, chunks.At(0).(ArrayInterface), 1, 2)
+	expectArray(t, chunks.At(2).(ArrayInterface), 5)
+
+	windows := NewQuery(New(1, 2, 3, 4)).Window(3).ToArray()
+	expect(t, windows.Length(), 2)
+	expectArray(t, windows.At(0).(ArrayInterface), 1, 2, 3)
+	expectArray(t, windows.At(1).(ArrayInterface), 2, 3, 4)
+	expect(t, NewQuery(New(1)).Window(2).Count(), 0)
+}
+
+func TestQueryTerminals(t *testing.T) {
+	q := NewQuery(New(3, 1.5, uint8(2)))
+	first, ok := q.First()
+	expect(t, first, 3)
+	expect(t, ok, true)
+	_, ok = NewQuery(New()).First()
+	expect(t, ok, false)
+	expect(t, q.Count(), 3)
+	sum, err := q.Sum()
+	expect(t, sum, 6.5)
+	expect(t, err, nil)
+	average, err := NewQuery(New(1, 2)).Average()
+	expect(t, average, 1.5)
+	expect(t, err, nil)
+	_, err = NewQuery(New()).Average()
+	expect(t, err, ErrEmpty)
+	_, err = NewQuery(New(1, "a")).Sum()
+	var numericError *NumericError
+	if !errors.As(err, &numericError) {
+		t.Fatal(err, "should be a *NumericError")
+	}
+	expect(t, numericError.Index, 1)
+	min, _ := q.Min(CompareNumbers)
+	expect(t, min, 1.5)
+	max, _ := q.Max(CompareNumbers)
+	expect(t, max, 3)
+	_, ok = NewQuery(New()).Max(CompareNumbers)
+	expect(t, ok, false)
+}
@@ -0,0 +1,149 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// The functions below draw random numbers from a math/rand/v2 source, so results
+// can be reproduced with a seeded source such as rand.NewPCG(1, 2).
+// A nil source uses the global, randomly seeded, generator of math/rand/v2.
+
+// globalSource is a rand.Source reading from the global generator of math/rand/v2
+type globalSource struct{}
+
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+// random returns a generator reading from source, or from the global generator if source is nil
+func random(source rand.Source) *rand.Rand {
+	if source == nil {
+		source = globalSource{}
+	}
+	return rand.New(source)
+}
+
+// ShuffleInPlace shuffles the elements of the array with the Fisher–Yates algorithm
+// and returns it
+func (a *Array) ShuffleInPlace(source rand.Source) ArrayInterface {
+	r := random(source)
+	for i := a.length - 1; i > 0; i-- {
+		x, y := a.index(i), a.index(r.IntN(i+1))
+		a.buffer[x], a.buffer[y] = a.buffer[y], a.buffer[x]
+	}
+	return a
+}
+
+// ToShuffled returns a shuffled copy of the array, the receiver is left untouched
+func (a *Array) ToShuffled(source rand.Source) ArrayInterface {
+	return a.slice(0, a.length).ShuffleInPlace(source)
+}
+
+// ShuffleInPlace shuffles the elements of the array and returns it
+func (s *SyncArray) ShuffleInPlace(source rand.Source) ArrayInterface {
+	s.write(func(a *Array) { a.ShuffleInPlace(source) })
+	return s
+}
+
+// ToShuffled returns a shuffled copy of the array
+func (s *SyncArray) ToShuffled(source rand.Source) ArrayInterface {
+	return s.Snapshot().ShuffleInPlace(source)
+}
+
+// Sample returns k distinct elements of an array picked at random, in random order.
+// Returns ErrNotEnoughElements if the array has less than k elements
+//
+// CAN PANIC if k is negative
+func Sample(a ArrayInterface, k int, source rand.Source) (ArrayInterface, error) {
+	if k < 0 {
+		panic("array: sample size must not be negative")
+	}
+	values := make([]interface{}, 0, a.Length())
+	for value := range a.Values() {
+		values = append(values, value)
+	}
+	if k > len(values) {
+		return nil, ErrNotEnoughElements
+	}
+	r := random(source)
+	for i := 0; i < k; i++ {
+		j := i + r.IntN(len(values)-i)
+		values[i], values[j] = values[j], values[i]
+	}
+	return New(values[:k]...), nil
+}
+
+// Choice returns an element of an array picked at random.
+// Returns ErrEmpty if the array is empty
+func Choice(a ArrayInterface, source rand.Source) (interface{}, error) {
+	if a.Length() == 0 {
+		return nil, ErrEmpty
+	}
+	return a.At(random(source).IntN(a.Length())), nil
+}
+
+// WeightedChoice returns an element of an array picked at random, with a probability
+// proportional to its weight. Returns ErrEmpty if the array is empty,
+// and an error if a weight is negative, infinite or NaN, or if all weights are 0
+func WeightedChoice(a ArrayInterface, weight func(value interface{}, i int) float64, source rand.Source) (interface{}, error) {
+	if a.Length() == 0 {
+		return nil, ErrEmpty
+	}
+	weights := make([]float64, 0, a.Length())
+	total := 0.0
+	for value := range a.Values() {
+		w := weight(value, len(weights))
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return nil, fmt.Errorf("array: invalid weight %v for element %d", w, len(weights))
+		}
+		weights = append(weights, w)
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("array: weights sum to 0")
+	}
+	target := random(source).Float64() * total
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if target < w {
+			return a.At(i), nil
+		}
+		target -= w
+		last = i
+	}
+	// rounding errors can leave target slightly above the last weight
+	return a.At(last), nil
+}
+
+// ReservoirSample picks k values at random from an iterator of unknown length
+// in a single pass, keeping only k values in memory. Returns all the values if
+// there are less than k of them. The values are not in a meaningful order
+//
+// CAN PANIC if k is negative
+func ReservoirSample(seq iter.Seq[interface{}], k int, source rand.Source) ArrayInterface {
+	if k < 0 {
+		panic("array: sample size must not be negative")
+	}
+	r := random(source)
+	reservoir := &Array{}
+	seen := 0
+	for value := range seq {
+		seen++
+		if reservoir.length < k {
+			reservoir.Push(value)
+		} else if j := r.IntN(seen); j < k {
+			reservoir.set(j, value)
+		}
+	}
+	return reservoir
+}
@@ -0,0 +1,143 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// expectFrequency checks that count out of trials is within 5% of probability
+func expectFrequency(t *testing.T, name interface{}, count, trials int, probability float64) {
+	t.Helper()
+	if frequency := float64(count) / float64(trials); math.Abs(frequency-probability) > probability*0.05 {
+		t.Error(name, "frequency", frequency, "should be close to", probability)
+	}
+}
+
+func TestShuffleIsReproducible(t *testing.T) {
+	a := New(1, 2, 3, 4, 5, 6, 7, 8)
+	first := a.ToShuffled(rand.NewPCG(1, 2))
+	second := a.ToShuffled(rand.NewPCG(1, 2))
+	expect(t, first, second)
+	expectArray(t, a, 1, 2, 3, 4, 5, 6, 7, 8)
+	expect(t, first.ToSorted(CompareNumbers), a)
+
+	a.Shift()
+	a.Push(9)
+	expectSame(t, a.ShuffleInPlace(rand.NewPCG(3, 4)), a)
+	expect(t, a.ToSorted(CompareNumbers), New(2, 3, 4, 5, 6, 7, 8, 9))
+	expect(t, New().ShuffleInPlace(nil).Length(), 0)
+}
+
+func TestShuffleDistribution(t *testing.T) {
+	source := rand.NewPCG(1, 2)
+	counts := map[string]int{}
+	const trials = 60000
+	for i := 0; i < trials; i++ {
+		counts[fmt.Sprint(New(1, 2, 3).ShuffleInPlace(source))]++
+	}
+	expect(t, len(counts), 6)
+	for permutation, count := range counts {
+		expectFrequency(t, permutation, count, trials, 1.0/6)
+	}
+}
+
+func TestSample(t *testing.T) {
+	source := rand.NewPCG(1, 2)
+	a := New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	counts := make([]int, 10)
+	const trials = 20000
+	for i := 0; i < trials; i++ {
+		sample, err := Sample(a, 3, source)
+		expect(t, err, nil)
+		expect(t, sample.Unique().Length(), 3)
+		for value := range sample.Values() {
+			counts[value.(int)]++
+		}
+	}
+	for value, count := range counts {
+		expectFrequency(t, value, count, trials, 0.3)
+	}
+	sample, _ := Sample(a, 10, source)
+	expect(t, sample.ToSorted(CompareNumbers), a)
+	sample, _ = Sample(a, 0, source)
+	expect(t, sample.Length(), 0)
+	if _, err := Sample(a, 11, source); !errors.Is(err, ErrNotEnoughElements) {
+		t.Error(err, "should be", ErrNotEnoughElements)
+	}
+}
+
+func TestChoice(t *testing.T) {
+	source := rand.NewPCG(1, 2)
+	counts := map[interface{}]int{}
+	const trials = 30000
+	for i := 0; i < trials; i++ {
+		value, err := Choice(New("a", "b", "c"), source)
+		expect(t, err, nil)
+		counts[value]++
+	}
+	for value, count := range counts {
+		expectFrequency(t, value, count, trials, 1.0/3)
+	}
+	if _, err := Choice(New(), source); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	source := rand.NewPCG(1, 2)
+	a := New(1.0, 0.0, 2.0, 7.0)
+	weight := func(v interface{}, i int) float64 { return v.(float64) }
+	counts := map[interface{}]int{}
+	const trials = 50000
+	for i := 0; i < trials; i++ {
+		value, err := WeightedChoice(a, weight, source)
+		expect(t, err, nil)
+		counts[value]++
+	}
+	expect(t, counts[0.0], 0)
+	for _, w := range []float64{1, 2, 7} {
+		expectFrequency(t, w, counts[w], trials, w/10)
+	}
+
+	if _, err := WeightedChoice(New(), weight, source); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+	for _, invalid := range []ArrayInterface{New(0.0, 0.0), New(1.0, -1.0), New(math.NaN()), New(math.Inf(1))} {
+		if _, err := WeightedChoice(invalid, weight, source); err == nil {
+			t.Error("WeightedChoice", invalid, "should fail")
+		}
+	}
+}
+
+func TestReservoirSample(t *testing.T) {
+	source := rand.NewPCG(1, 2)
+	counts := make([]int, 10)
+	const trials = 20000
+	for i := 0; i < trials; i++ {
+		sample := ReservoirSample(numbers(10).Values(), 3, source)
+		expect(t, sample.Length(), 3)
+		for value := range sample.Values() {
+			counts[value.(int)]++
+		}
+	}
+	for value, count := range counts {
+		expectFrequency(t, value, count, trials, 0.3)
+	}
+	expect(t, ReservoirSample(New(1, 2).Values(), 3, source), New(1, 2))
+	expect(t, ReservoirSample(New(1, 2).Values(), 0, source).Length(), 0)
+	expect(t, ReservoirSample(New(1, 2).Values(), math.MaxInt, source), New(1, 2))
+}
+
+func TestSyncArrayShuffle(t *testing.T) {
+	s := NewSync(1, 2, 3, 4)
+	expect(t, s.ToShuffled(rand.NewPCG(1, 2)), New(1, 2, 3, 4).ToShuffled(rand.NewPCG(1, 2)))
+	s.ShuffleInPlace(rand.NewPCG(1, 2))
+	expect(t, s.Snapshot(), New(1, 2, 3, 4).ToShuffled(rand.NewPCG(1, 2)))
+}
@@ -0,0 +1,265 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"fmt"
+	"iter"
+)
+
+// The methods below expect an array sorted in ascending order according to compare,
+// for instance with SortStable(compare). They run in O(log n),
+// except insertions and removals which also move O(n) elements.
+
+// IsSorted returns true if the array is sorted in ascending order according to compare
+func (a *Array) IsSorted(compare Comparator) bool {
+	for i := 1; i < a.length; i++ {
+		if compare(a.get(i), a.get(i-1)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearch searches value in the sorted array and returns the index of the first
+// equivalent element and true, or the index where value would be inserted and false
+func (a *Array) BinarySearch(value interface{}, compare Comparator) (int, bool) {
+	i := a.LowerBound(value, compare)
+	return i, i < a.length && compare(a.get(i), value) == 0
+}
+
+// LowerBound returns the index of the first element of the sorted array
+// which is not less than value, or Length() if there is none
+func (a *Array) LowerBound(value interface{}, compare Comparator) int {
+	return a.search(func(el interface{}) bool { return compare(el, value) >= 0 })
+}
+
+// UpperBound returns the index of the first element of the sorted array
+// which is greater than value, or Length() if there is none
+func (a *Array) UpperBound(value interface{}, compare Comparator) int {
+	return a.search(func(el interface{}) bool { return compare(el, value) > 0 })
+}
+
+// search returns the smallest index for which found is true,
+// found must be false then true along the array
+func (a *Array) search(found func(interface{}) bool) int {
+	low, high := 0, a.length
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if found(a.get(middle)) {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+	return low
+}
+
+// InsertSorted inserts value after its equivalent elements so the array stays sorted,
+// and returns its index
+func (a *Array) InsertSorted(value interface{}, compare Comparator) int {
+	i := a.UpperBound(value, compare)
+	a.insert(i, value)
+	return i
+}
+
+// RemoveSorted removes the first element equivalent to value from the sorted array
+// and returns its index, or -1 if there is none
+func (a *Array) RemoveSorted(value interface{}, compare Comparator) int {
+	i, found := a.BinarySearch(value, compare)
+	if !found {
+		return -1
+	}
+	a.remove(i)
+	return i
+}
+
+// MergeSorted merges the sorted array and other, which must be sorted too,
+// into a new sorted array. Equivalent elements of the array come first
+func (a *Array) MergeSorted(other ArrayInterface, compare Comparator) ArrayInterface {
+	result := NewWithCapacity(a.length + other.Length()).(*Array)
+	i := 0
+	for value := range other.Values() {
+		for ; i < a.length && compare(a.get(i), value) <= 0; i++ {
+			result.Push(a.get(i))
+		}
+		result.Push(value)
+	}
+	for ; i < a.length; i++ {
+		result.Push(a.get(i))
+	}
+	return result
+}
+
+// IsSorted returns true if the array is sorted according to compare,
+// compare must not use the SyncArray
+func (s *SyncArray) IsSorted(compare Comparator) (sorted bool) {
+	s.read(func(a *Array) { sorted = a.IsSorted(compare) })
+	return sorted
+}
+
+// BinarySearch searches value in the sorted array, see Array.BinarySearch
+func (s *SyncArray) BinarySearch(value interface{}, compare Comparator) (i int, found bool) {
+	s.read(func(a *Array) { i, found = a.BinarySearch(value, compare) })
+	return i, found
+}
+
+// LowerBound returns the index of the first element which is not less than value
+func (s *SyncArray) LowerBound(value interface{}, compare Comparator) (i int) {
+	s.read(func(a *Array) { i = a.LowerBound(value, compare) })
+	return i
+}
+
+// UpperBound returns the index of the first element which is greater than value
+func (s *SyncArray) UpperBound(value interface{}, compare Comparator) (i int) {
+	s.read(func(a *Array) { i = a.UpperBound(value, compare) })
+	return i
+}
+
+// InsertSorted inserts value so the array stays sorted and returns its index,
+// the search and the insertion happen under the same lock
+func (s *SyncArray) InsertSorted(value interface{}, compare Comparator) (i int) {
+	s.write(func(a *Array) { i = a.InsertSorted(value, compare) })
+	return i
+}
+
+// RemoveSorted removes the first element equivalent to value and returns its index,
+// or -1 if there is none
+func (s *SyncArray) RemoveSorted(value interface{}, compare Comparator) (i int) {
+	s.write(func(a *Array) { i = a.RemoveSorted(value, compare) })
+	return i
+}
+
+// MergeSorted merges a snapshot of the array and other into a new sorted array
+func (s *SyncArray) MergeSorted(other ArrayInterface, compare Comparator) ArrayInterface {
+	return s.Snapshot().MergeSorted(other, compare)
+}
+
+// SortedArray is an array which stays sorted: values are inserted at their place
+// instead of being pushed, so lookups can use a binary search.
+//
+//	scores := NewSorted(CompareNumbers, 40, 10, 30)
+//	scores.Insert(20)             // [10, 20, 30, 40]
+//	i, found := scores.Search(30) // 2, true
+//
+// Equivalent values are kept in insertion order. A SortedArray is not safe
+// for concurrent use.
+type SortedArray struct {
+	array   *Array
+	compare Comparator
+}
+
+// NewSorted returns a sorted array holding values, compare defaults to CompareNatural
+func NewSorted(compare Comparator, values ...interface{}) *SortedArray {
+	if compare == nil {
+		compare = CompareNatural
+	}
+	s := &SortedArray{array: NewWithCapacity(len(values)).(*Array), compare: compare}
+	s.array.Push(values...)
+	s.array.SortStable(compare)
+	return s
+}
+
+// Insert inserts values at their place and returns the new length
+func (s *SortedArray) Insert(values ...interface{}) int {
+	for _, value := range values {
+		s.array.InsertSorted(value, s.compare)
+	}
+	return s.array.length
+}
+
+// Remove removes the first value equivalent to value, returns false if there is none
+func (s *SortedArray) Remove(value interface{}) bool {
+	return s.array.RemoveSorted(value, s.compare) >= 0
+}
+
+// RemoveAt removes the value at index and returns it, negative indexes count back
+// from the end. Returns an *IndexError if index is out of range
+func (s *SortedArray) RemoveAt(index int) (interface{}, error) {
+	i := index
+	if i < 0 {
+		i += s.array.length
+	}
+	if i < 0 || i >= s.array.length {
+		return nil, &IndexError{Index: index, Length: s.array.length}
+	}
+	return s.array.remove(i), nil
+}
+
+// Search returns the index of the first value equivalent to value and true,
+// or the index where value would be inserted and false
+func (s *SortedArray) Search(value interface{}) (int, bool) {
+	return s.array.BinarySearch(value, s.compare)
+}
+
+// Contains returns true if a value equivalent to value is in the array
+func (s *SortedArray) Contains(value interface{}) bool {
+	_, found := s.Search(value)
+	return found
+}
+
+// Count returns the number of values equivalent to value
+func (s *SortedArray) Count(value interface{}) int {
+	return s.array.UpperBound(value, s.compare) - s.array.LowerBound(value, s.compare)
+}
+
+// Range returns the values greater than or equal to low and less than high
+func (s *SortedArray) Range(low, high interface{}) ArrayInterface {
+	begin := s.array.LowerBound(low, s.compare)
+	end := s.array.LowerBound(high, s.compare)
+	if end < begin {
+		end = begin
+	}
+	return s.array.slice(begin, end)
+}
+
+// At returns the value at index, negative indexes count back from the end
+func (s *SortedArray) At(index int) interface{} {
+	return s.array.At(index)
+}
+
+// Length returns the number of values
+func (s *SortedArray) Length() int {
+	return s.array.length
+}
+
+// Min returns the smallest value, ok is false if the array is empty
+func (s *SortedArray) Min() (value interface{}, ok bool) {
+	if s.array.length == 0 {
+		return nil, false
+	}
+	return s.array.get(0), true
+}
+
+// Max returns the largest value, ok is false if the array is empty
+func (s *SortedArray) Max() (value interface{}, ok bool) {
+	if s.array.length == 0 {
+		return nil, false
+	}
+	return s.array.get(s.array.length - 1), true
+}
+
+// Values returns an iterator over the values in ascending order
+func (s *SortedArray) Values() iter.Seq[interface{}] {
+	return s.array.Values()
+}
+
+// Merge inserts the values of other, which may be unsorted, and returns the new length.
+// Equivalent values of other come after those of the array, in the order of other
+func (s *SortedArray) Merge(other ArrayInterface) int {
+	values := New(other.ArrayInterface()...).SortStable(s.compare)
+	s.array = s.array.MergeSorted(values, s.compare).(*Array)
+	return s.array.length
+}
+
+// ToArray returns the values as a new array
+func (s *SortedArray) ToArray() ArrayInterface {
+	return s.array.slice(0, s.array.length)
+}
+
+// String returns the values formatted like an array
+func (s *SortedArray) String() string {
+	return fmt.Sprint(s.array)
+}
@@ -0,0 +1,163 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestBinarySearch(t *testing.T) {
+	a := New(1, 3, 3, 3, 5, 8)
+	type fixture struct {
+		value        interface{}
+		index        int
+		found        bool
+		lower, upper int
+	}
+	for _, fix := range []fixture{
+		{0, 0, false, 0, 0},
+		{1, 0, true, 0, 1},
+		{3, 1, true, 1, 4},
+		{4, 4, false, 4, 4},
+		{8, 5, true, 5, 6},
+		{9, 6, false, 6, 6},
+		{3.0, 1, true, 1, 4},
+	} {
+		index, found := a.BinarySearch(fix.value, CompareNumbers)
+		if index != fix.index || found != fix.found {
+			t.Error("BinarySearch", fix.value, index, found, "should be", fix.index, fix.found)
+		}
+		expect(t, a.LowerBound(fix.value, CompareNumbers), fix.lower)
+		expect(t, a.UpperBound(fix.value, CompareNumbers), fix.upper)
+	}
+	index, found := New().BinarySearch(1, CompareNumbers)
+	expect(t, index, 0)
+	expect(t, found, false)
+}
+
+func TestIsSorted(t *testing.T) {
+	expect(t, New().IsSorted(CompareNumbers), true)
+	expect(t, New(1, 1, 2).IsSorted(CompareNumbers), true)
+	expect(t, New(1, 3, 2).IsSorted(CompareNumbers), false)
+	expect(t, New(3, 2, 1).IsSorted(Descending(CompareNumbers)), true)
+}
+
+func TestInsertRemoveSorted(t *testing.T) {
+	a := New()
+	r := rand.New(rand.NewPCG(1, 2))
+	var expected []int
+	for i := 0; i < 200; i++ {
+		value := r.IntN(50)
+		index := a.InsertSorted(value, CompareNumbers)
+		expect(t, a.At(index), value)
+		expected = append(expected, value)
+	}
+	slices.Sort(expected)
+	expectArray(t, a, toInterfaces(expected)...)
+
+	for i := 0; i < 150; i++ {
+		value := r.IntN(60)
+		index := a.RemoveSorted(value, CompareNumbers)
+		if j, found := slices.BinarySearch(expected, value); found {
+			expect(t, index, j)
+			expected = slices.Delete(expected, j, j+1)
+		} else {
+			expect(t, index, -1)
+		}
+	}
+	expectArray(t, a, toInterfaces(expected)...)
+	expect(t, a.IsSorted(CompareNumbers), true)
+}
+
+func TestInsertSortedKeepsInsertionOrder(t *testing.T) {
+	byLength := func(a, b interface{}) int { return len(a.(string)) - len(b.(string)) }
+	a := New("a", "bbb")
+	expect(t, a.InsertSorted("cc", byLength), 1)
+	expect(t, a.InsertSorted("dd", byLength), 2)
+	expect(t, a.InsertSorted("e", byLength), 1)
+	expectArray(t, a, "a", "e", "cc", "dd", "bbb")
+}
+
+func TestMergeSorted(t *testing.T) {
+	expectArray(t, New(1, 4, 6).MergeSorted(New(2, 4, 5, 9), CompareNumbers), 1, 2, 4, 4, 5, 6, 9)
+	expectArray(t, New().MergeSorted(New(1, 2), CompareNumbers), 1, 2)
+	expectArray(t, New(1, 2).MergeSorted(New(), CompareNumbers), 1, 2)
+	// equivalent elements of the receiver come first
+	expectArray(t, New(1, 2).MergeSorted(New(1.0), CompareNumbers), 1, 1.0, 2)
+}
+
+func TestSortedArray(t *testing.T) {
+	s := NewSorted(CompareNumbers, 40, 10, 30)
+	expect(t, s.Insert(20, 30), 5)
+	expectArray(t, s.ToArray(), 10, 20, 30, 30, 40)
+	expect(t, s.String(), "ArrayInterface[10, 20, 30, 30, 40]")
+
+	index, found := s.Search(30)
+	expect(t, index, 2)
+	expect(t, found, true)
+	expect(t, s.Contains(25), false)
+	expect(t, s.Count(30), 2)
+	expectArray(t, s.Range(15, 40), 20, 30, 30)
+	expectArray(t, s.Range(40, 15))
+	expect(t, s.At(-1), 40)
+
+	expect(t, s.Remove(30), true)
+	expect(t, s.Remove(35), false)
+	value, err := s.RemoveAt(0)
+	expect(t, value, 10)
+	expect(t, err, nil)
+	if _, err := s.RemoveAt(3); err == nil {
+		t.Error("RemoveAt out of range should fail")
+	}
+
+	expect(t, s.Merge(New(50, 5, 25)), 6)
+	expectArray(t, s.ToArray(), 5, 20, 25, 30, 40, 50)
+	min, _ := s.Min()
+	max, _ := s.Max()
+	expect(t, min, 5)
+	expect(t, max, 50)
+	_, ok := NewSorted(nil).Min()
+	expect(t, ok, false)
+}
+
+func TestSortedArrayMergeIsStable(t *testing.T) {
+	byKey := func(a, b interface{}) int { return a.([2]int)[0] - b.([2]int)[0] }
+	s := NewSorted(byKey, [2]int{1, 0}, [2]int{2, 0})
+	other := New()
+	for i := 1; i <= 20; i++ {
+		other.Push([2]int{2 - i%2, i})
+	}
+	s.Merge(other)
+	expect(t, s.Length(), 22)
+	previous := [2]int{0, -1}
+	for value := range s.Values() {
+		pair := value.([2]int)
+		if pair[0] < previous[0] || pair[0] == previous[0] && pair[1] < previous[1] {
+			t.Fatal(s, "should keep equivalent values in insertion order")
+		}
+		previous = pair
+	}
+}
+
+func TestSyncArraySortedOperations(t *testing.T) {
+	s := NewSync(1, 5)
+	expect(t, s.InsertSorted(3, CompareNumbers), 1)
+	expect(t, s.IsSorted(CompareNumbers), true)
+	index, found := s.BinarySearch(5, CompareNumbers)
+	expect(t, index, 2)
+	expect(t, found, true)
+	expect(t, s.RemoveSorted(1, CompareNumbers), 0)
+	expectArray(t, s.MergeSorted(New(4), CompareNumbers), 3, 4, 5)
+}
+
+func toInterfaces(values []int) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
@@ -0,0 +1,269 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"iter"
+	"reflect"
+)
+
+// valueSet is a set of values. Hashable values are stored in a map,
+// other values in a slice searched with equal
+type valueSet struct {
+	hashed map[interface{}]struct{}
+	others []interface{}
+	equal  EqualityFunc
+}
+
+// newValueSet returns an empty set, unhashable values are compared with equal
+func newValueSet(equal EqualityFunc) *valueSet {
+	return &valueSet{hashed: map[interface{}]struct{}{}, equal: equal}
+}
+
+// isHashable returns true if value can be used as a map key.
+// Arrays are pointers but are compared by content, so they are not hashable
+func isHashable(value interface{}) bool {
+	if _, ok := value.(ArrayInterface); ok {
+		return false
+	}
+	return value == nil || reflect.ValueOf(value).Comparable()
+}
+
+// has returns true if value is in the set
+func (s *valueSet) has(value interface{}) bool {
+	if isHashable(value) {
+		_, ok := s.hashed[value]
+		return ok
+	}
+	for _, other := range s.others {
+		if s.equal(other, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds value to the set, returns false if it was already there
+func (s *valueSet) add(value interface{}) bool {
+	if s.has(value) {
+		return false
+	}
+	if isHashable(value) {
+		s.hashed[value] = struct{}{}
+	} else {
+		s.others = append(s.others, value)
+	}
+	return true
+}
+
+// KeyMap maps keys to values, remembering the order keys are first set in.
+// Keys are compared like Unique compares elements: hashable keys with ==,
+// arrays and unhashable keys such as slices or maps with DeepEqual.
+// The zero value is an empty map ready to use
+type KeyMap[V any] struct {
+	keys   []interface{}
+	values []V
+	hashed map[interface{}]int
+	others []int
+}
+
+// index returns the position of key, or -1 if it is not in the map
+func (m *KeyMap[V]) index(key interface{}) int {
+	if isHashable(key) {
+		if i, ok := m.hashed[key]; ok {
+			return i
+		}
+		return -1
+	}
+	for _, i := range m.others {
+		if DeepEqual(m.keys[i], key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// entry returns the value of key, adding key with the zero value if it is not in the map
+func (m *KeyMap[V]) entry(key interface{}) *V {
+	i := m.index(key)
+	if i < 0 {
+		i = len(m.keys)
+		m.keys = append(m.keys, key)
+		m.values = append(m.values, *new(V))
+		if isHashable(key) {
+			if m.hashed == nil {
+				m.hashed = map[interface{}]int{}
+			}
+			m.hashed[key] = i
+		} else {
+			m.others = append(m.others, i)
+		}
+	}
+	return &m.values[i]
+}
+
+// Get returns the value of key, ok is false if key is not in the map
+func (m *KeyMap[V]) Get(key interface{}) (value V, ok bool) {
+	if i := m.index(key); i >= 0 {
+		return m.values[i], true
+	}
+	return value, false
+}
+
+// Set sets the value of key, a new key is added after the existing ones
+func (m *KeyMap[V]) Set(key interface{}, value V) {
+	*m.entry(key) = value
+}
+
+// Len returns the number of keys
+func (m *KeyMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the keys in the order they were first set
+func (m *KeyMap[V]) Keys() []interface{} {
+	return append([]interface{}(nil), m.keys...)
+}
+
+// All returns an iterator over the keys and values in the order keys were first set
+func (m *KeyMap[V]) All() iter.Seq2[interface{}, V] {
+	return func(yield func(interface{}, V) bool) {
+		for i, key := range m.keys {
+			if !yield(key, m.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// equalityOrDefault returns the first equality function or DeepEqual
+func equalityOrDefault(equal []EqualityFunc) EqualityFunc {
+	if len(equal) > 0 && equal[0] != nil {
+		return equal[0]
+	}
+	return DeepEqual
+}
+
+// valueSetOf returns a set of the values of a
+func valueSetOf(a ArrayInterface, equal EqualityFunc) *valueSet {
+	set := newValueSet(equal)
+	for value := range a.Values() {
+		set.add(value)
+	}
+	return set
+}
+
+// Unique returns a new array without duplicated elements, keeping the first occurrence.
+// Hashable elements are compared with ==, other elements such as slices with equal,
+// which defaults to DeepEqual
+func (a *Array) Unique(equal ...EqualityFunc) ArrayInterface {
+	return a.UniqueBy(func(value interface{}) interface{} { return value }, equal...)
+}
+
+// UniqueBy returns a new array keeping the first element for each result of key.
+// Keys are compared like Unique compares elements
+func (a *Array) UniqueBy(key func(interface{}) interface{}, equal ...EqualityFunc) ArrayInterface {
+	seen := newValueSet(equalityOrDefault(equal))
+	result := &Array{}
+	for i := 0; i < a.length; i++ {
+		if value := a.get(i); seen.add(key(value)) {
+			result.Push(value)
+		}
+	}
+	return result
+}
+
+// Union returns the unique elements of the array followed by the unique elements
+// of other not in the array. Elements are compared like Unique does
+func (a *Array) Union(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	seen := newValueSet(equalityOrDefault(equal))
+	result := &Array{}
+	for value := range a.Values() {
+		if seen.add(value) {
+			result.Push(value)
+		}
+	}
+	for value := range other.Values() {
+		if seen.add(value) {
+			result.Push(value)
+		}
+	}
+	return result
+}
+
+// Intersect returns the unique elements of the array which are also in other.
+// Elements are compared like Unique does
+func (a *Array) Intersect(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	return a.filterUnique(valueSetOf(other, equalityOrDefault(equal)), true)
+}
+
+// Difference returns the unique elements of the array which are not in other.
+// Elements are compared like Unique does
+func (a *Array) Difference(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	return a.filterUnique(valueSetOf(other, equalityOrDefault(equal)), false)
+}
+
+// SymmetricDifference returns the unique elements of the array which are not in other,
+// followed by the unique elements of other which are not in the array.
+// Elements are compared like Unique does
+func (a *Array) SymmetricDifference(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	eq := equalityOrDefault(equal)
+	otherValues := valueSetOf(other, eq)
+	result := a.filterUnique(otherValues, false).(*Array)
+	values := valueSetOf(a, eq)
+	seen := newValueSet(eq)
+	for value := range other.Values() {
+		if !values.has(value) && seen.add(value) {
+			result.Push(value)
+		}
+	}
+	return result
+}
+
+// filterUnique returns the unique elements of the array which are in set if keep is true,
+// or not in set if keep is false
+func (a *Array) filterUnique(set *valueSet, keep bool) ArrayInterface {
+	seen := newValueSet(set.equal)
+	result := &Array{}
+	for i := 0; i < a.length; i++ {
+		value := a.get(i)
+		if set.has(value) == keep && seen.add(value) {
+			result.Push(value)
+		}
+	}
+	return result
+}
+
+// Unique returns a new array without duplicated elements, see Array.Unique
+func (s *SyncArray) Unique(equal ...EqualityFunc) ArrayInterface {
+	return s.Snapshot().Unique(equal...)
+}
+
+// UniqueBy returns a new array keeping the first element for each result of key,
+// see Array.UniqueBy
+func (s *SyncArray) UniqueBy(key func(interface{}) interface{}, equal ...EqualityFunc) ArrayInterface {
+	return s.Snapshot().UniqueBy(key, equal...)
+}
+
+// Union returns the union of the array and other, see Array.Union
+func (s *SyncArray) Union(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	return s.Snapshot().Union(other, equal...)
+}
+
+// Intersect returns the intersection of the array and other, see Array.Intersect
+func (s *SyncArray) Intersect(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	return s.Snapshot().Intersect(other, equal...)
+}
+
+// Difference returns the elements of the array which are not in other, see Array.Difference
+func (s *SyncArray) Difference(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	return s.Snapshot().Difference(other, equal...)
+}
+
+// SymmetricDifference returns the elements which are in only one of the arrays,
+// see Array.SymmetricDifference
+func (s *SyncArray) SymmetricDifference(other ArrayInterface, equal ...EqualityFunc) ArrayInterface {
+	return s.Snapshot().SymmetricDifference(other, equal...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	a, b := New(3, 1, 2, 1, 3), New(4, 2, 5, 4)
+	type fixture struct {
+		name     string
+		actual   ArrayInterface
+		expected []interface{}
+	}
+	for _, fix := range []fixture{
+		{"Unique", a.Unique(), []interface{}{3, 1, 2}},
+		{"Union", a.Union(b), []interface{}{3, 1, 2, 4, 5}},
+		{"Intersect", a.Intersect(b), []interface{}{2}},
+		{"Difference", a.Difference(b), []interface{}{3, 1}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []interface{}{3, 1, 4, 5}},
+		{"Unique empty", New().Unique(), []interface{}{}},
+		{"Union empty", New().Union(New(1, 1)), []interface{}{1}},
+		{"Unique nil", New(nil, 1, nil).Unique(), []interface{}{nil, 1}},
+		{"Unique mixed types", New(1, "1", 1.0, 1).Unique(), []interface{}{1, "1", 1.0}},
+	} {
+		if !fix.actual.Equal(New(fix.expected...)) {
+			t.Error(fix.name, fix.actual, "should be", fix.expected)
+		}
+	}
+}
+
+func TestSetOperationsUnhashable(t *testing.T) {
+	a := New([]int{1}, []int{2}, []int{1}, New(3), New(3))
+	unique := a.Unique()
+	expect(t, unique.Length(), 3)
+	expect(t, unique.At(2), New(3))
+	expect(t, a.Intersect(New([]int{2}, 4)).Length(), 1)
+	expect(t, a.Difference(New([]int{2}, New(3))), New([]int{1}))
+
+	// a custom equality applies to unhashable elements only
+	sameLength := func(x, y interface{}) bool {
+		return len(x.([]int)) == len(y.([]int))
+	}
+	expect(t, New([]int{1}, []int{2}, []int{3, 4}, 1, 1).Unique(sameLength), New([]int{1}, []int{3, 4}, 1))
+}
+
+func TestUniqueBy(t *testing.T) {
+	a := New("apple", "Avocado", "banana", "blueberry", "cherry")
+	firstLetter := func(v interface{}) interface{} {
+		return strings.ToLower(v.(string)[:1])
+	}
+	expect(t, a.UniqueBy(firstLetter), New("apple", "banana", "cherry"))
+	bySlice := func(v interface{}) interface{} {
+		return []byte(strings.ToLower(v.(string)[:1]))
+	}
+	expect(t, a.UniqueBy(bySlice), New("apple", "banana", "cherry"))
+}
+
+func TestSyncArraySetOperations(t *testing.T) {
+	s := NewSync(1, 2, 2)
+	expect(t, s.Unique(), New(1, 2))
+	expect(t, s.Union(NewSync(3)), New(1, 2, 3))
+	expect(t, New(2, 3).Intersect(s), New(2))
+	expect(t, s.SymmetricDifference(New(2, 3)), New(1, 3))
+}
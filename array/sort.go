@@ -0,0 +1,88 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Comparator compares 2 values, it returns a negative number when a < b,
+// a positive number when a > b and 0 when a and b are equivalent
+type Comparator func(a, b interface{}) int
+
+// CompareNumbers compares values of any Go numeric kind, int and float64 can be mixed.
+// NaN is less than any other number.
+//
+// CAN PANIC if a value is not a number
+func CompareNumbers(a, b interface{}) int {
+	x, ok := toNumber(a)
+	if !ok {
+		panic(fmt.Sprintf("array: can't compare %+v, it is not a number", a))
+	}
+	y, ok := toNumber(b)
+	if !ok {
+		panic(fmt.Sprintf("array: can't compare %+v, it is not a number", b))
+	}
+	return compareNumbers(x, y)
+}
+
+// CompareStrings compares strings, including named string types, lexicographically
+//
+// CAN PANIC if a value is not a string
+func CompareStrings(a, b interface{}) int {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+	if x.Kind() != reflect.String {
+		panic(fmt.Sprintf("array: can't compare %+v, it is not a string", a))
+	}
+	if y.Kind() != reflect.String {
+		panic(fmt.Sprintf("array: can't compare %+v, it is not a string", b))
+	}
+	return strings.Compare(x.String(), y.String())
+}
+
+// Descending reverses the order of a comparator
+func Descending(compare Comparator) Comparator {
+	return func(a, b interface{}) int {
+		return compare(b, a)
+	}
+}
+
+// Sort sorts a copy of the array given a compare function
+// returning true if a is less than b
+func (a *Array) Sort(compareFunc func(a, b interface{}) bool) ArrayInterface {
+	result := a.slice(0, a.length)
+	slices.SortFunc(result.contiguous(), func(x, y interface{}) int {
+		switch {
+		case compareFunc(x, y):
+			return -1
+		case compareFunc(y, x):
+			return 1
+		}
+		return 0
+	})
+	return result
+}
+
+// SortInPlace sorts the array in O(n log n) and returns it.
+// The order of equivalent values is not preserved
+func (a *Array) SortInPlace(compare Comparator) ArrayInterface {
+	slices.SortFunc(a.contiguous(), compare)
+	return a
+}
+
+// SortStable sorts the array in place, keeping the original order
+// of equivalent values, and returns it
+func (a *Array) SortStable(compare Comparator) ArrayInterface {
+	slices.SortStableFunc(a.contiguous(), compare)
+	return a
+}
+
+// ToSorted returns a sorted copy of the array, the receiver is left untouched
+func (a *Array) ToSorted(compare Comparator) ArrayInterface {
+	return a.slice(0, a.length).SortInPlace(compare)
+}
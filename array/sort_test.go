@@ -0,0 +1,93 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	type fixture struct {
+		a, b     interface{}
+		expected int
+	}
+	for _, fix := range []fixture{
+		{1, 2, -1},
+		{2.5, 2, 1},
+		{int8(3), uint64(3), 0},
+		{-1, uint(0), -1},
+		{uint(1 << 63), int64(math.MaxInt64), 1},
+		{int64(math.MaxInt64), int64(math.MaxInt64 - 1), 1},
+		{math.NaN(), math.Inf(-1), -1},
+		{weekday(2), 1, 1},
+	} {
+		expect(t, CompareNumbers(fix.a, fix.b), fix.expected)
+	}
+}
+
+func TestCompareNumbersPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("CompareNumbers should panic on non numeric values")
+		}
+	}()
+	CompareNumbers(1, "1")
+}
+
+func TestCompareStrings(t *testing.T) {
+	type name string
+	expect(t, CompareStrings("a", "b"), -1)
+	expect(t, CompareStrings(name("b"), "a"), 1)
+	expect(t, CompareStrings("a", "a"), 0)
+	expect(t, Descending(CompareStrings)("a", "b"), 1)
+}
+
+func TestSortInPlace(t *testing.T) {
+	a := New(3, 1.5, 2, uint8(0))
+	// wrap values around the ring buffer
+	a.Unshift(10)
+	sorted := a.SortInPlace(CompareNumbers)
+	expect(t, sorted, a)
+	for i, v := range []interface{}{uint8(0), 1.5, 2, 3, 10} {
+		expect(t, a.At(i), v)
+	}
+	a.SortInPlace(Descending(CompareNumbers))
+	expect(t, a.At(0), 10)
+}
+
+func TestSortStable(t *testing.T) {
+	type pair struct {
+		key   int
+		value string
+	}
+	a := New(pair{2, "a"}, pair{1, "b"}, pair{2, "c"}, pair{1, "d"})
+	a.SortStable(func(x, y interface{}) int {
+		return CompareNumbers(x.(pair).key, y.(pair).key)
+	})
+	for i, v := range []string{"b", "d", "a", "c"} {
+		expect(t, a.At(i).(pair).value, v)
+	}
+}
+
+func TestSortDoesNotMutate(t *testing.T) {
+	a := New(3, 2, 1)
+	sorted := a.Sort(func(x, y interface{}) bool { return x.(int) < y.(int) })
+	expect(t, a.At(0), 3)
+	expect(t, sorted.At(0), 1)
+}
+
+func BenchmarkSortInPlace(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	values := make([]interface{}, 10000)
+	for i := range values {
+		values[i] = random.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		New(values...).SortInPlace(CompareNumbers)
+	}
+}
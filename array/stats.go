@@ -0,0 +1,341 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// The functions below treat arrays as samples of numbers. Elements can be of any
+// Go numeric kind, including named types, and are converted to float64.
+// They return a *NumericError for the first element which is not a number.
+// NaN elements are missing values: they are skipped, so an array holding only NaN
+// is treated as empty.
+
+// floats returns the elements of an array as float64 values, without NaN
+func floats(a ArrayInterface) ([]float64, error) {
+	values := make([]float64, 0, a.Length())
+	i := 0
+	for value := range a.Values() {
+		n, ok := toNumber(value)
+		if !ok {
+			return nil, &NumericError{Index: i, Value: value}
+		}
+		if f := n.float(); !math.IsNaN(f) {
+			values = append(values, f)
+		}
+		i++
+	}
+	return values, nil
+}
+
+// sortedFloats returns the elements of an array as sorted float64 values without NaN,
+// returns ErrEmpty if there are none
+func sortedFloats(a ArrayInterface) ([]float64, error) {
+	values, err := floats(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, ErrEmpty
+	}
+	slices.Sort(values)
+	return values, nil
+}
+
+// Sum returns the sum of the elements, 0 for an empty array
+func Sum(a ArrayInterface) (float64, error) {
+	values, err := floats(a)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum, nil
+}
+
+// Product returns the product of the elements, 1 for an empty array
+func Product(a ArrayInterface) (float64, error) {
+	values, err := floats(a)
+	if err != nil {
+		return 0, err
+	}
+	product := 1.0
+	for _, value := range values {
+		product *= value
+	}
+	return product, nil
+}
+
+// Min returns the smallest element and its index, the first one if several are equal.
+// Integers are compared without converting them to float64.
+// Returns ErrEmpty if the array is empty
+func Min(a ArrayInterface) (value float64, index int, err error) {
+	return extremum(a, -1)
+}
+
+// Max returns the largest element and its index, the first one if several are equal.
+// Integers are compared without converting them to float64.
+// Returns ErrEmpty if the array is empty
+func Max(a ArrayInterface) (value float64, index int, err error) {
+	return extremum(a, 1)
+}
+
+// extremum returns the smallest element if sign is -1, the largest if sign is 1
+func extremum(a ArrayInterface, sign int) (float64, int, error) {
+	var best number
+	index := -1
+	i := 0
+	for value := range a.Values() {
+		n, ok := toNumber(value)
+		if !ok {
+			return 0, 0, &NumericError{Index: i, Value: value}
+		}
+		if !math.IsNaN(n.float()) && (index < 0 || compareNumbers(n, best)*sign > 0) {
+			best, index = n, i
+		}
+		i++
+	}
+	if index < 0 {
+		return 0, 0, ErrEmpty
+	}
+	return best.float(), index, nil
+}
+
+// Mean returns the arithmetic mean of the elements.
+// Returns ErrEmpty if the array is empty
+func Mean(a ArrayInterface) (float64, error) {
+	values, err := floats(a)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	return mean(values), nil
+}
+
+// mean returns the mean of values, which must not be empty
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// Median returns the middle element, or the mean of the 2 middle elements
+// when the length is even. Returns ErrEmpty if the array is empty
+func Median(a ArrayInterface) (float64, error) {
+	return Percentile(a, 50, LinearInterpolation)
+}
+
+// Mode returns the most frequent elements in ascending order,
+// several if they are equally frequent. Returns ErrEmpty if the array is empty
+func Mode(a ArrayInterface) ([]float64, error) {
+	values, err := sortedFloats(a)
+	if err != nil {
+		return nil, err
+	}
+	var modes []float64
+	best := 0
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		switch count := j - i; {
+		case count > best:
+			best, modes = count, append(modes[:0], values[i])
+		case count == best:
+			modes = append(modes, values[i])
+		}
+		i = j
+	}
+	return modes, nil
+}
+
+// Interpolation tells Percentile how to compute a percentile falling between 2 elements
+type Interpolation int
+
+const (
+	// LinearInterpolation interpolates linearly between the 2 elements
+	LinearInterpolation Interpolation = iota
+	// LowerInterpolation takes the lower element
+	LowerInterpolation
+	// HigherInterpolation takes the higher element
+	HigherInterpolation
+	// NearestInterpolation takes the nearest element, the one with an even rank when halfway
+	NearestInterpolation
+	// MidpointInterpolation takes the mean of the 2 elements
+	MidpointInterpolation
+)
+
+// Percentile returns the pth percentile of the elements, p being between 0 and 100.
+// The percentile is at rank p/100*(Length()-1) in the sorted elements,
+// method tells how to compute it when the rank falls between 2 elements.
+// Returns ErrEmpty if the array is empty
+func Percentile(a ArrayInterface, p float64, method Interpolation) (float64, error) {
+	if !(p >= 0 && p <= 100) {
+		return 0, fmt.Errorf("array: percentile %v is not between 0 and 100", p)
+	}
+	values, err := sortedFloats(a)
+	if err != nil {
+		return 0, err
+	}
+	rank := p / 100 * float64(len(values)-1)
+	lower, higher := values[int(math.Floor(rank))], values[int(math.Ceil(rank))]
+	switch method {
+	case LinearInterpolation:
+		return lower + (higher-lower)*(rank-math.Floor(rank)), nil
+	case LowerInterpolation:
+		return lower, nil
+	case HigherInterpolation:
+		return higher, nil
+	case NearestInterpolation:
+		return values[int(math.RoundToEven(rank))], nil
+	case MidpointInterpolation:
+		return (lower + higher) / 2, nil
+	}
+	return 0, fmt.Errorf("array: unknown interpolation method %d", method)
+}
+
+// Variance returns the population variance of the elements.
+// Returns ErrEmpty if the array is empty
+func Variance(a ArrayInterface) (float64, error) {
+	return variance(a, 0)
+}
+
+// SampleVariance returns the sample variance of the elements, with Bessel's correction.
+// Returns ErrNotEnoughElements if the array has less than 2 elements
+func SampleVariance(a ArrayInterface) (float64, error) {
+	return variance(a, 1)
+}
+
+// StdDev returns the population standard deviation of the elements.
+// Returns ErrEmpty if the array is empty
+func StdDev(a ArrayInterface) (float64, error) {
+	v, err := Variance(a)
+	return math.Sqrt(v), err
+}
+
+// SampleStdDev returns the sample standard deviation of the elements.
+// Returns ErrNotEnoughElements if the array has less than 2 elements
+func SampleStdDev(a ArrayInterface) (float64, error) {
+	v, err := SampleVariance(a)
+	return math.Sqrt(v), err
+}
+
+// variance returns the sum of squared deviations from the mean divided by n - ddof
+func variance(a ArrayInterface, ddof int) (float64, error) {
+	values, err := floats(a)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	if len(values) <= ddof {
+		return 0, ErrNotEnoughElements
+	}
+	m := mean(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - m) * (value - m)
+	}
+	return sum / float64(len(values)-ddof), nil
+}
+
+// CumulativeSum returns a new array where each element is the sum of the elements
+// up to the same index. NaN elements stay NaN and don't change the following sums
+func CumulativeSum(a ArrayInterface) (ArrayInterface, error) {
+	result := NewWithCapacity(a.Length())
+	sum := 0.0
+	for value := range a.Values() {
+		n, ok := toNumber(value)
+		if !ok {
+			return nil, &NumericError{Index: result.Length(), Value: value}
+		}
+		if f := n.float(); math.IsNaN(f) {
+			result.Push(f)
+		} else {
+			sum += f
+			result.Push(sum)
+		}
+	}
+	return result, nil
+}
+
+// Bin is a histogram bin, counting the elements in [Low, High).
+// The last bin of a histogram also counts the elements equal to its High
+type Bin struct {
+	Low, High float64
+	Count     int
+}
+
+// Histogram counts the elements in bins of equal width between the smallest
+// and the largest element. If bins is 0 or less, the number of bins is chosen with
+// Sturges' rule, log2(n)+1, n not counting NaN elements.
+// Returns ErrEmpty if the array is empty
+func Histogram(a ArrayInterface, bins int) ([]Bin, error) {
+	values, err := sortedFloats(a)
+	if err != nil {
+		return nil, err
+	}
+	if bins <= 0 {
+		bins = int(math.Ceil(math.Log2(float64(len(values))))) + 1
+	}
+	low, high := values[0], values[len(values)-1]
+	if low == high {
+		low, high = low-0.5, high+0.5
+	}
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = low + (high-low)*float64(i)/float64(bins)
+	}
+	edges[bins] = high
+	return histogram(values, edges), nil
+}
+
+// HistogramWithEdges counts the elements in the bins delimited by edges.
+// Elements outside of the edges are not counted.
+// Returns an error if there are less than 2 edges or if they are not in ascending order
+func HistogramWithEdges(a ArrayInterface, edges ...float64) ([]Bin, error) {
+	if len(edges) < 2 {
+		return nil, fmt.Errorf("array: a histogram needs at least 2 edges, got %d", len(edges))
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] >= edges[i-1]) {
+			return nil, fmt.Errorf("array: histogram edges %v are not in ascending order", edges)
+		}
+	}
+	values, err := floats(a)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(values)
+	return histogram(values, edges), nil
+}
+
+// histogram counts sorted values in the bins delimited by edges
+func histogram(values []float64, edges []float64) []Bin {
+	bins := make([]Bin, len(edges)-1)
+	for i := range bins {
+		bins[i].Low, bins[i].High = edges[i], edges[i+1]
+		begin, _ := slices.BinarySearch(values, edges[i])
+		end, _ := slices.BinarySearch(values, edges[i+1])
+		if i == len(bins)-1 {
+			for end < len(values) && values[end] == edges[i+1] {
+				end++
+			}
+		}
+		bins[i].Count = max(end-begin, 0)
+	}
+	return bins
+}
@@ -0,0 +1,235 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func expectFloat(t *testing.T, actual, expected float64) {
+	t.Helper()
+	if math.Abs(actual-expected) > 1e-9 {
+		t.Error(actual, "should be", expected)
+	}
+}
+
+func TestSumProductMean(t *testing.T) {
+	mixed := New(1, 2.5, uint8(3), int64(-4), float32(0.5), weekday(2))
+	sum, err := Sum(mixed)
+	expect(t, err, nil)
+	expectFloat(t, sum, 5)
+	product, _ := Product(New(2, 2.5, uint(4)))
+	expectFloat(t, product, 20)
+	mean, _ := Mean(mixed)
+	expectFloat(t, mean, 5.0/6)
+
+	sum, _ = Sum(New())
+	expectFloat(t, sum, 0)
+	product, _ = Product(New())
+	expectFloat(t, product, 1)
+	if _, err := Mean(New()); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+}
+
+func TestStatsNonNumeric(t *testing.T) {
+	a := New(1, 2, "3")
+	for name, fn := range map[string]func(ArrayInterface) error{
+		"Sum":           func(a ArrayInterface) error { _, err := Sum(a); return err },
+		"Min":           func(a ArrayInterface) error { _, _, err := Min(a); return err },
+		"Median":        func(a ArrayInterface) error { _, err := Median(a); return err },
+		"Mode":          func(a ArrayInterface) error { _, err := Mode(a); return err },
+		"Variance":      func(a ArrayInterface) error { _, err := Variance(a); return err },
+		"CumulativeSum": func(a ArrayInterface) error { _, err := CumulativeSum(a); return err },
+		"Histogram":     func(a ArrayInterface) error { _, err := Histogram(a, 0); return err },
+	} {
+		err := fn(a)
+		var numericErr *NumericError
+		if !errors.As(err, &numericErr) || numericErr.Index != 2 || !errors.Is(err, ErrNotNumeric) {
+			t.Error(name, err, "should be a *NumericError for index 2")
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	a := New(3, 1.5, int64(9), 1.5, uint(9))
+	value, index, err := Min(a)
+	expect(t, err, nil)
+	expectFloat(t, value, 1.5)
+	expect(t, index, 1)
+	value, index, _ = Max(a)
+	expectFloat(t, value, 9)
+	expect(t, index, 2)
+	// integers are compared exactly
+	_, index, _ = Max(New(int64(1<<53+1), int64(1<<53)))
+	expect(t, index, 0)
+	if _, _, err := Max(New()); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+}
+
+func TestMedianMode(t *testing.T) {
+	median, _ := Median(New(5, 1, 3))
+	expectFloat(t, median, 3)
+	median, _ = Median(New(4, 1, 3, 2))
+	expectFloat(t, median, 2.5)
+
+	modes, _ := Mode(New(3, 1, 3, 2, 1.0))
+	expect(t, modes, []float64{1, 3})
+	modes, _ = Mode(New(7))
+	expect(t, modes, []float64{7})
+	if _, err := Mode(New()); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	a := New(40, 10, 20, 30)
+	type fixture struct {
+		p        float64
+		method   Interpolation
+		expected float64
+	}
+	for _, fix := range []fixture{
+		{0, LinearInterpolation, 10},
+		{100, LinearInterpolation, 40},
+		{50, LinearInterpolation, 25},
+		{40, LinearInterpolation, 22},
+		{40, LowerInterpolation, 20},
+		{40, HigherInterpolation, 30},
+		{40, NearestInterpolation, 20},
+		{50, NearestInterpolation, 30},
+		{60, NearestInterpolation, 30},
+		{40, MidpointInterpolation, 25},
+		{100, HigherInterpolation, 40},
+	} {
+		actual, err := Percentile(a, fix.p, fix.method)
+		if err != nil || math.Abs(actual-fix.expected) > 1e-9 {
+			t.Error("Percentile", fix.p, fix.method, actual, err, "should be", fix.expected)
+		}
+	}
+	for _, p := range []float64{-1, 101, math.NaN()} {
+		if _, err := Percentile(a, p, LinearInterpolation); err == nil {
+			t.Error("Percentile", p, "should fail")
+		}
+	}
+	if _, err := Percentile(a, 50, Interpolation(42)); err == nil {
+		t.Error("unknown interpolation should fail")
+	}
+}
+
+func TestVariance(t *testing.T) {
+	a := New(2, 4, 4, 4, 5, 5, 7, 9)
+	variance, _ := Variance(a)
+	expectFloat(t, variance, 4)
+	stddev, _ := StdDev(a)
+	expectFloat(t, stddev, 2)
+	variance, _ = SampleVariance(a)
+	expectFloat(t, variance, 32.0/7)
+	stddev, _ = SampleStdDev(a)
+	expectFloat(t, stddev, math.Sqrt(32.0/7))
+
+	variance, err := Variance(New(3))
+	expect(t, err, nil)
+	expectFloat(t, variance, 0)
+	if _, err := SampleVariance(New(3)); !errors.Is(err, ErrNotEnoughElements) {
+		t.Error(err, "should be", ErrNotEnoughElements)
+	}
+	if _, err := StdDev(New()); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+}
+
+func TestCumulativeSum(t *testing.T) {
+	sums, err := CumulativeSum(New(1, 2.5, uint(3)))
+	expect(t, err, nil)
+	expectArray(t, sums, 1.0, 3.5, 6.5)
+	sums, _ = CumulativeSum(NewSync())
+	expect(t, sums.Length(), 0)
+}
+
+func TestHistogram(t *testing.T) {
+	a := New(0, 1, 2, 2, 3, 9, 10)
+	bins, err := Histogram(a, 2)
+	expect(t, err, nil)
+	expect(t, bins, []Bin{{0, 5, 5}, {5, 10, 2}})
+
+	// Sturges' rule: ceil(log2(7)) + 1 = 4 bins
+	bins, _ = Histogram(a, 0)
+	expect(t, len(bins), 4)
+	expect(t, bins[3], Bin{7.5, 10, 2})
+
+	bins, _ = Histogram(New(3, 3), 1)
+	expect(t, bins, []Bin{{2.5, 3.5, 2}})
+	bins, _ = Histogram(New(1, math.NaN(), 2), 1)
+	expect(t, bins, []Bin{{1, 2, 2}})
+	// NaN elements don't count in Sturges' rule: ceil(log2(2)) + 1 = 2 bins
+	bins, _ = Histogram(New(1, math.NaN(), math.NaN(), math.NaN(), 2), 0)
+	expect(t, bins, []Bin{{1, 1.5, 1}, {1.5, 2, 1}})
+	if _, err := Histogram(New(), 3); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+
+	bins, _ = HistogramWithEdges(a, 1, 3, 9)
+	expect(t, bins, []Bin{{1, 3, 3}, {3, 9, 2}})
+	bins, _ = HistogramWithEdges(New(), 0, 1)
+	expect(t, bins, []Bin{{0, 1, 0}})
+	bins, _ = HistogramWithEdges(New(math.NaN(), 0.5), 0, 1)
+	expect(t, bins, []Bin{{0, 1, 1}})
+	for _, edges := range [][]float64{nil, {1}, {2, 1}, {0, math.NaN()}} {
+		if _, err := HistogramWithEdges(a, edges...); err == nil {
+			t.Error("HistogramWithEdges", edges, "should fail")
+		}
+	}
+}
+
+func TestStatsSkipNaN(t *testing.T) {
+	nan := math.NaN()
+	a := New(nan, 3, 1, nan, 2, nan)
+	type fixture struct {
+		name     string
+		fn       func(ArrayInterface) (float64, error)
+		expected float64
+	}
+	for _, fix := range []fixture{
+		{"Sum", Sum, 6},
+		{"Product", Product, 6},
+		{"Mean", Mean, 2},
+		{"Median", Median, 2},
+		{"Percentile", func(a ArrayInterface) (float64, error) { return Percentile(a, 100, LowerInterpolation) }, 3},
+		{"Variance", Variance, 2.0 / 3},
+	} {
+		actual, err := fix.fn(a)
+		if err != nil || math.Abs(actual-fix.expected) > 1e-9 {
+			t.Error(fix.name, actual, err, "should be", fix.expected)
+		}
+	}
+	value, index, _ := Min(a)
+	expectFloat(t, value, 1)
+	expect(t, index, 2)
+	value, index, _ = Max(a)
+	expectFloat(t, value, 3)
+	expect(t, index, 1)
+	modes, _ := Mode(New(nan, nan, 1))
+	expect(t, modes, []float64{1})
+
+	sums, _ := CumulativeSum(New(1, nan, 2))
+	expectFloat(t, sums.At(0).(float64), 1)
+	expect(t, math.IsNaN(sums.At(1).(float64)), true)
+	expectFloat(t, sums.At(2).(float64), 3)
+
+	onlyNaN := New(nan, nan)
+	if _, err := Median(onlyNaN); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+	if _, _, err := Min(onlyNaN); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+	if _, err := Histogram(onlyNaN, 0); !errors.Is(err, ErrEmpty) {
+		t.Error(err, "should be", ErrEmpty)
+	}
+}
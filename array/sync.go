@@ -0,0 +1,402 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"sync"
+)
+
+// SyncArray is an ArrayInterface safe for concurrent use by multiple goroutines.
+//
+// Methods taking a callback run it on a snapshot of the array taken under the lock,
+// so callbacks may call methods of the SyncArray without deadlocking. Methods returning
+// a new array return a plain *Array. A few methods call user code while holding the lock,
+// which must therefore not use the SyncArray: the callback of Update, the compare function
+// of SortInPlace, SortStable, InsertSorted and RemoveSorted (write lock) and of IsSorted,
+// BinarySearch, LowerBound and UpperBound (read lock), and the source of ShuffleInPlace.
+type SyncArray struct {
+	mutex sync.RWMutex
+	array *Array
+}
+
+var _ ArrayInterface = (*SyncArray)(nil)
+
+// NewSync returns a new thread-safe array
+func NewSync(values ...interface{}) *SyncArray {
+	return &SyncArray{array: New(values...).(*Array)}
+}
+
+// read runs fn with the read lock held
+func (s *SyncArray) read(fn func(a *Array)) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	fn(s.array)
+}
+
+// write runs fn with the write lock held
+func (s *SyncArray) write(fn func(a *Array)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	fn(s.array)
+}
+
+// Snapshot returns a copy of the array
+func (s *SyncArray) Snapshot() *Array {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.array.slice(0, s.array.length)
+}
+
+// PushIfAbsent pushes value if it is not already in the array, elements are compared
+// with StrictEqual. Returns true if value was pushed
+func (s *SyncArray) PushIfAbsent(value interface{}) (pushed bool) {
+	s.write(func(a *Array) {
+		if !a.Includes(value) {
+			a.Push(value)
+			pushed = true
+		}
+	})
+	return pushed
+}
+
+// Update atomically replaces the element at index with the result of fn.
+// A negative index counts back from the end of the array.
+// Returns an *IndexError if index is out of range
+func (s *SyncArray) Update(index int, fn func(value interface{}) interface{}) (err error) {
+	s.write(func(a *Array) {
+		if index < 0 {
+			index += a.length
+		}
+		if index < 0 || index >= a.length {
+			err = &IndexError{Index: index, Length: a.length}
+			return
+		}
+		a.set(index, fn(a.get(index)))
+	})
+	return err
+}
+
+// Drain atomically removes all the elements and returns them
+func (s *SyncArray) Drain() ArrayInterface {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	result := s.array
+	s.array = &Array{}
+	return result
+}
+
+// Push put values at the end of array
+// returns the number of values added
+func (s *SyncArray) Push(values ...interface{}) (n int) {
+	s.write(func(a *Array) { n = a.Push(values...) })
+	return n
+}
+
+// Pop remove the last value of the array
+func (s *SyncArray) Pop() (value interface{}) {
+	s.write(func(a *Array) { value = a.Pop() })
+	return value
+}
+
+// TryPop remove the last value of the array, ok is false if the array is empty
+func (s *SyncArray) TryPop() (value interface{}, ok bool) {
+	s.write(func(a *Array) { value, ok = a.TryPop() })
+	return value, ok
+}
+
+// PopE remove the last value of the array, returns ErrEmpty if the array is empty
+func (s *SyncArray) PopE() (value interface{}, err error) {
+	s.write(func(a *Array) { value, err = a.PopE() })
+	return value, err
+}
+
+// Shift removes the first element of the array and returns it
+func (s *SyncArray) Shift() (value interface{}) {
+	s.write(func(a *Array) { value = a.Shift() })
+	return value
+}
+
+// TryShift removes the first element of the array and returns it,
+// ok is false if the array is empty
+func (s *SyncArray) TryShift() (value interface{}, ok bool) {
+	s.write(func(a *Array) { value, ok = a.TryShift() })
+	return value, ok
+}
+
+// ShiftE removes the first element of the array and returns it,
+// returns ErrEmpty if the array is empty
+func (s *SyncArray) ShiftE() (value interface{}, err error) {
+	s.write(func(a *Array) { value, err = a.ShiftE() })
+	return value, err
+}
+
+// Unshift add elements at index 0 and returns the number of added elements
+func (s *SyncArray) Unshift(values ...interface{}) (n int) {
+	s.write(func(a *Array) { n = a.Unshift(values...) })
+	return n
+}
+
+// Splice remove elements from the array at a given index and optionally insert new elements
+func (s *SyncArray) Splice(start int, deleteCount int, items ...interface{}) (removed ArrayInterface) {
+	s.write(func(a *Array) { removed = a.Splice(start, deleteCount, items...) })
+	return removed
+}
+
+// Fill replaces elements from begin to end (excluded) with value and returns the array
+func (s *SyncArray) Fill(value interface{}, beginAndEndValues ...int) ArrayInterface {
+	s.write(func(a *Array) { a.Fill(value, beginAndEndValues...) })
+	return s
+}
+
+// CopyWithin copies the elements from begin to end (excluded) to the target index
+// and returns the array
+func (s *SyncArray) CopyWithin(target int, beginAndEndValues ...int) ArrayInterface {
+	s.write(func(a *Array) { a.CopyWithin(target, beginAndEndValues...) })
+	return s
+}
+
+// ReverseInPlace reverse the order of the elements of the array and returns it
+func (s *SyncArray) ReverseInPlace() ArrayInterface {
+	s.write(func(a *Array) { a.ReverseInPlace() })
+	return s
+}
+
+// SortInPlace sorts the array and returns it, compare must not use the SyncArray
+func (s *SyncArray) SortInPlace(compare Comparator) ArrayInterface {
+	s.write(func(a *Array) { a.SortInPlace(compare) })
+	return s
+}
+
+// SortStable sorts the array keeping the order of equivalent values and returns it,
+// compare must not use the SyncArray
+func (s *SyncArray) SortStable(compare Comparator) ArrayInterface {
+	s.write(func(a *Array) { a.SortStable(compare) })
+	return s
+}
+
+// Cap returns the number of elements the array can hold without allocating
+func (s *SyncArray) Cap() (capacity int) {
+	s.read(func(a *Array) { capacity = a.Cap() })
+	return capacity
+}
+
+// Grow makes room for n more elements
+func (s *SyncArray) Grow(n int) {
+	s.write(func(a *Array) { a.Grow(n) })
+}
+
+// Clip releases the unused capacity of the array
+func (s *SyncArray) Clip() {
+	s.write(func(a *Array) { a.Clip() })
+}
+
+// Clear removes all the elements, keeping the capacity of the array
+func (s *SyncArray) Clear() {
+	s.write(func(a *Array) { a.Clear() })
+}
+
+// Truncate removes the elements from index n, keeping the capacity of the array
+func (s *SyncArray) Truncate(n int) {
+	s.write(func(a *Array) { a.Truncate(n) })
+}
+
+// At get a value at index
+func (s *SyncArray) At(index int) (value interface{}) {
+	s.read(func(a *Array) { value = a.At(index) })
+	return value
+}
+
+// TryAt get a value at index, ok is false if index is out of range
+func (s *SyncArray) TryAt(index int) (value interface{}, ok bool) {
+	s.read(func(a *Array) { value, ok = a.TryAt(index) })
+	return value, ok
+}
+
+// AtE get a value at index, returns an *IndexError if index is out of range
+func (s *SyncArray) AtE(index int) (value interface{}, err error) {
+	s.read(func(a *Array) { value, err = a.AtE(index) })
+	return value, err
+}
+
+// Length returns the number of elements of the array
+func (s *SyncArray) Length() (length int) {
+	s.read(func(a *Array) { length = a.Length() })
+	return length
+}
+
+// Slice returns a copy of a portion of the array
+func (s *SyncArray) Slice(beginAndEndValues ...int) (result ArrayInterface) {
+	s.read(func(a *Array) { result = a.Slice(beginAndEndValues...) })
+	return result
+}
+
+// Reverse returns a reversed copy of the array
+func (s *SyncArray) Reverse() (result ArrayInterface) {
+	s.read(func(a *Array) { result = a.Reverse() })
+	return result
+}
+
+// ToReversed returns a reversed copy of the array
+func (s *SyncArray) ToReversed() ArrayInterface {
+	return s.Reverse()
+}
+
+// ToSpliced returns a spliced copy of the array
+func (s *SyncArray) ToSpliced(start int, deleteCount int, items ...interface{}) (result ArrayInterface) {
+	s.read(func(a *Array) { result = a.ToSpliced(start, deleteCount, items...) })
+	return result
+}
+
+// With returns a copy of the array where the element at index is replaced with value
+func (s *SyncArray) With(index int, value interface{}) (result ArrayInterface, err error) {
+	s.read(func(a *Array) { result, err = a.With(index, value) })
+	return result, err
+}
+
+// IndexOf returns the first index of searchElement starting at fromIndex, or -1
+func (s *SyncArray) IndexOf(searchElement interface{}, fromIndex int) (index int) {
+	s.read(func(a *Array) { index = a.IndexOf(searchElement, fromIndex) })
+	return index
+}
+
+// LastIndexOf returns the last index of searchElement searching backwards from fromIndex, or -1
+func (s *SyncArray) LastIndexOf(searchElement interface{}, fromIndex int) (index int) {
+	s.read(func(a *Array) { index = a.LastIndexOf(searchElement, fromIndex) })
+	return index
+}
+
+// Includes returns true if searchElement is in the array
+func (s *SyncArray) Includes(searchElement interface{}) (found bool) {
+	s.read(func(a *Array) { found = a.Includes(searchElement) })
+	return found
+}
+
+// Keys returns a new array with the indexes of the array
+func (s *SyncArray) Keys() (result ArrayInterface) {
+	s.read(func(a *Array) { result = a.Keys() })
+	return result
+}
+
+// Entries returns a new array of New(index, value) pairs
+func (s *SyncArray) Entries() (result ArrayInterface) {
+	s.read(func(a *Array) { result = a.Entries() })
+	return result
+}
+
+// ArrayInterface returns a copy of the elements as a slice
+func (s *SyncArray) ArrayInterface() (values []interface{}) {
+	s.read(func(a *Array) { values = a.ArrayInterface() })
+	return values
+}
+
+// ForEach execute callback on each element of a snapshot of the array
+func (s *SyncArray) ForEach(callback func(value interface{}, i int)) {
+	s.Snapshot().ForEach(callback)
+}
+
+// Filter filters elements of a snapshot of the array given a predicate
+func (s *SyncArray) Filter(predicate func(interface{}, int) bool) ArrayInterface {
+	return s.Snapshot().Filter(predicate)
+}
+
+// Map maps the elements of a snapshot of the array into a new Array
+func (s *SyncArray) Map(callback func(value interface{}, i int) interface{}) ArrayInterface {
+	return s.Snapshot().Map(callback)
+}
+
+// Reduce folds a snapshot of the array into a single value
+func (s *SyncArray) Reduce(callback func(result interface{}, value interface{}, index int) interface{}, initial interface{}) interface{} {
+	return s.Snapshot().Reduce(callback, initial)
+}
+
+// ReduceRight folds a snapshot of the array into a single value, starting from the end
+func (s *SyncArray) ReduceRight(callback func(result interface{}, value interface{}, index int) interface{}, initial interface{}) interface{} {
+	return s.Snapshot().ReduceRight(callback, initial)
+}
+
+// Some returns true if the callback predicate is satisfied
+func (s *SyncArray) Some(callback func(v interface{}, index int) bool) bool {
+	return s.Snapshot().Some(callback)
+}
+
+// Every returns true if the callback predicate is true for every element of the array
+func (s *SyncArray) Every(callback func(v interface{}, index int) bool) bool {
+	return s.Snapshot().Every(callback)
+}
+
+// Find returns the first element satisfying the predicate, ok is false if there is none
+func (s *SyncArray) Find(predicate func(interface{}, int) bool) (interface{}, bool) {
+	return s.Snapshot().Find(predicate)
+}
+
+// FindIndex returns the index of the first element satisfying the predicate or -1
+func (s *SyncArray) FindIndex(predicate func(interface{}, int) bool) int {
+	return s.Snapshot().FindIndex(predicate)
+}
+
+// FindLast returns the last element satisfying the predicate, ok is false if there is none
+func (s *SyncArray) FindLast(predicate func(interface{}, int) bool) (interface{}, bool) {
+	return s.Snapshot().FindLast(predicate)
+}
+
+// FindLastIndex returns the index of the last element satisfying the predicate or -1
+func (s *SyncArray) FindLastIndex(predicate func(interface{}, int) bool) int {
+	return s.Snapshot().FindLastIndex(predicate)
+}
+
+// IndexOfFunc is like IndexOf but compares elements with equal
+func (s *SyncArray) IndexOfFunc(searchElement interface{}, fromIndex int, equal EqualityFunc) int {
+	return s.Snapshot().IndexOfFunc(searchElement, fromIndex, equal)
+}
+
+// IncludesFunc is like Includes but compares elements with equal
+func (s *SyncArray) IncludesFunc(searchElement interface{}, equal EqualityFunc) bool {
+	return s.Snapshot().IncludesFunc(searchElement, equal)
+}
+
+// Equal returns true if other has the same length and deeply equal elements
+func (s *SyncArray) Equal(other ArrayInterface) bool {
+	return s.Snapshot().Equal(other)
+}
+
+// Compare compares arrays lexicographically
+func (s *SyncArray) Compare(other ArrayInterface, compare Comparator) int {
+	return s.Snapshot().Compare(other, compare)
+}
+
+// Concat returns a new array with the elements of the array followed by the elements of arrays
+func (s *SyncArray) Concat(arrays ...ArrayInterface) ArrayInterface {
+	return s.Snapshot().Concat(arrays...)
+}
+
+// Sort returns a sorted copy of the array
+func (s *SyncArray) Sort(compareFunc func(a, b interface{}) bool) ArrayInterface {
+	return s.Snapshot().Sort(compareFunc)
+}
+
+// ToSorted returns a sorted copy of the array
+func (s *SyncArray) ToSorted(compare Comparator) ArrayInterface {
+	return s.Snapshot().SortInPlace(compare)
+}
+
+// Flat returns a new array with nested arrays flattened up to depth
+func (s *SyncArray) Flat(depth int) ArrayInterface {
+	return s.Snapshot().Flat(depth)
+}
+
+// FlatMap maps each element with callback then flattens the result by 1 level
+func (s *SyncArray) FlatMap(callback func(interface{}, int) interface{}) ArrayInterface {
+	return s.Snapshot().FlatMap(callback)
+}
+
+// Join concatenates the elements of the array, separated by separator
+func (s *SyncArray) Join(separator string) string {
+	return s.Snapshot().Join(separator)
+}
+
+// String returns a string representation of the array
+func (s *SyncArray) String() string {
+	return s.Snapshot().String()
+}
@@ -0,0 +1,112 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package array
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSyncArrayConcurrentPushShift(t *testing.T) {
+	s := NewSync()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s.Push(i*1000 + j)
+				s.At(0)
+				s.Length()
+				if j%2 == 0 {
+					s.Shift()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	expect(t, s.Length(), 4000)
+}
+
+func TestSyncArrayCallbacksCanMutate(t *testing.T) {
+	s := NewSync(1, 2, 3)
+	s.ForEach(func(v interface{}, i int) {
+		s.Push(v.(int) * 10)
+	})
+	expectArray(t, s.Snapshot(), 1, 2, 3, 10, 20, 30)
+	filtered := s.Filter(func(v interface{}, i int) bool {
+		s.Pop()
+		return v.(int) < 10
+	})
+	expectArray(t, filtered, 1, 2, 3)
+	expect(t, s.Length(), 0)
+}
+
+func TestSyncArrayCompoundOperations(t *testing.T) {
+	s := NewSync(1)
+	expect(t, s.PushIfAbsent(1), false)
+	expect(t, s.PushIfAbsent(2), true)
+	expectArray(t, s.Snapshot(), 1, 2)
+
+	if err := s.Update(-1, func(v interface{}) interface{} { return v.(int) + 1 }); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, s.At(1), 3)
+	if err := s.Update(2, func(v interface{}) interface{} { return v }); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Error(err, "should be", ErrIndexOutOfRange)
+	}
+
+	drained := s.Drain()
+	expectArray(t, drained, 1, 3)
+	expect(t, s.Length(), 0)
+	s.Push(4)
+	expect(t, drained.Length(), 2)
+}
+
+func TestSyncArrayConcurrentUpdate(t *testing.T) {
+	s := NewSync(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Update(0, func(v interface{}) interface{} { return v.(int) + 1 })
+				s.PushIfAbsent(strconv.Itoa(j))
+			}
+		}()
+	}
+	wg.Wait()
+	expect(t, s.At(0), 800)
+	expect(t, s.Length(), 101)
+}
+
+func TestSyncArrayMethods(t *testing.T) {
+	s := NewSync(3, 1, 2)
+	expectSame(t, s.SortInPlace(CompareNumbers), s)
+	expectArray(t, s.Snapshot(), 1, 2, 3)
+	expectArray(t, s.ToSorted(Descending(CompareNumbers)), 3, 2, 1)
+	expectArray(t, s.Concat(s), 1, 2, 3, 1, 2, 3)
+	expect(t, s.Equal(New(1, 2, 3)), true)
+	expect(t, New(1, 2, 3).Equal(s), true)
+	expect(t, s.Join("-"), "1-2-3")
+	expect(t, s.String(), "ArrayInterface[1, 2, 3]")
+	copied, _ := NewFrom(s)
+	expectArray(t, copied, 1, 2, 3)
+}
+
+func TestSyncArrayCapacity(t *testing.T) {
+	s := NewSync(1, 2, 3)
+	s.Grow(10)
+	expect(t, s.Cap(), 13)
+	s.Truncate(1)
+	expectArray(t, s.Snapshot(), 1)
+	s.Clip()
+	expect(t, s.Cap(), 1)
+	s.Clear()
+	expect(t, s.Length(), 0)
+}
@@ -46,6 +46,8 @@ Array is a alternative structure for the default array implementation
 	},0)
     // folds the array into a single value,in that case returns the sum of all elements
     // 7+6+5+1+2+3 = 24
+    // array.Sum(array) does the same for elements of any numeric kind, see also
+    // Mean, Median, Percentile, Variance and Histogram
 
     doubleArray:=array.Map(func(element interface{},index int)interface{
 		return element.(int)*2
@@ -0,0 +1,279 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package typed provides a type-parameterized counterpart of array.Array.
+//
+// Array[T] exposes the same javascript-like methods as array.ArrayInterface,
+// without the type assertions. Functions that change the element type
+// (Map, Reduce, ReduceRight) are free functions since Go methods can't
+// declare their own type parameters.
+package typed
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/interactiv/datastruct/array"
+)
+
+// Array is a type-safe alternative to array.Array
+type Array[T any] struct {
+	array []T
+}
+
+// New returns a new array
+func New[T any](values ...T) *Array[T] {
+	return &Array[T]{array: append([]T(nil), values...)}
+}
+
+// FromArrayInterface creates an Array[T] from an array.ArrayInterface.
+// It returns an *array.ElementTypeError if an element isn't a T.
+func FromArrayInterface[T any](a array.ArrayInterface) (*Array[T], error) {
+	values, err := array.SliceOf[T](a)
+	if err != nil {
+		return nil, err
+	}
+	return &Array[T]{array: values}, nil
+}
+
+// ArrayInterface converts the array into an array.ArrayInterface
+func (a *Array[T]) ArrayInterface() array.ArrayInterface {
+	values := make([]interface{}, len(a.array))
+	for i, value := range a.array {
+		values[i] = value
+	}
+	return array.New(values...)
+}
+
+// Values returns a copy of the underlying slice
+func (a *Array[T]) Values() []T {
+	return append([]T{}, a.array...)
+}
+
+// At get a value at index, ok is false if index is out of range
+func (a *Array[T]) At(index int) (value T, ok bool) {
+	if index < len(a.array) && index >= 0 {
+		return a.array[index], true
+	}
+	return value, false
+}
+
+// Push put values at the end of array
+// returns the number of values added
+func (a *Array[T]) Push(values ...T) int {
+	a.array = append(a.array, values...)
+	return len(values)
+}
+
+// Pop remove the last value of the array, ok is false if the array is empty
+func (a *Array[T]) Pop() (value T, ok bool) {
+	if len(a.array) == 0 {
+		return value, false
+	}
+	value = a.array[len(a.array)-1]
+	var zero T
+	a.array[len(a.array)-1] = zero
+	a.array = a.array[:len(a.array)-1]
+	return value, true
+}
+
+// Length returns the number of elements of the array
+func (a *Array[T]) Length() int {
+	return len(a.array)
+}
+
+// Shift removes the first element of the array and returns it,
+// ok is false if the array is empty
+func (a *Array[T]) Shift() (value T, ok bool) {
+	if len(a.array) == 0 {
+		return value, false
+	}
+	value = a.array[0]
+	var zero T
+	a.array[0] = zero
+	a.array = a.array[1:]
+	return value, true
+}
+
+// Unshift add elements at index 0 and returns the number of added elements
+// like array.Array, values are inserted one after the other so
+// Unshift(1, 2) on [3] gives [2, 1, 3]
+func (a *Array[T]) Unshift(values ...T) int {
+	result := make([]T, 0, len(values)+len(a.array))
+	for i := len(values) - 1; i >= 0; i-- {
+		result = append(result, values[i])
+	}
+	a.array = append(result, a.array...)
+	return len(values)
+}
+
+// ForEach execute callback on each element of the array
+func (a *Array[T]) ForEach(callback func(value T, i int)) {
+	for i := 0; i < len(a.array); i++ {
+		callback(a.array[i], i)
+	}
+}
+
+// Filter filters elements given a predicate
+func (a *Array[T]) Filter(predicate func(value T, i int) bool) *Array[T] {
+	result := New[T]()
+	for i, value := range a.array {
+		if predicate(value, i) {
+			result.array = append(result.array, value)
+		}
+	}
+	return result
+}
+
+// Slice returns a copy of a portion of the array
+// It takes up to 2 arguments :
+//   - begin int
+//   - end int (excluded)
+func (a *Array[T]) Slice(beginAndEndValues ...int) *Array[T] {
+	begin, end := 0, len(a.array)
+	if len(beginAndEndValues) > 0 {
+		begin = relativeIndex(beginAndEndValues[0], len(a.array))
+	}
+	if len(beginAndEndValues) > 1 {
+		end = relativeIndex(beginAndEndValues[1], len(a.array))
+	}
+	if end <= begin {
+		return New[T]()
+	}
+	return New(a.array[begin:end]...)
+}
+
+// Splice remove elements from the array at a given index and optionally insert new elements
+// returns the removed elements
+func (a *Array[T]) Splice(start int, deleteCount int, items ...T) *Array[T] {
+	start = relativeIndex(start, len(a.array))
+	if deleteCount < 0 {
+		deleteCount = 0
+	}
+	if start+deleteCount > len(a.array) {
+		deleteCount = len(a.array) - start
+	}
+	removed := New(a.array[start : start+deleteCount]...)
+	tail := append([]T{}, a.array[start+deleteCount:]...)
+	a.array = append(append(a.array[:start], items...), tail...)
+	return removed
+}
+
+// Some returns true if the callback predicate is satisfied
+func (a *Array[T]) Some(callback func(value T, i int) bool) bool {
+	for i, value := range a.array {
+		if callback(value, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every returns true if the callback predicate is true for every element of the array
+func (a *Array[T]) Every(callback func(value T, i int) bool) bool {
+	for i, value := range a.array {
+		if !callback(value, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reverse reverse the order of the elements of the array and returns a new one
+func (a *Array[T]) Reverse() *Array[T] {
+	result := &Array[T]{array: make([]T, 0, len(a.array))}
+	for i := len(a.array) - 1; i >= 0; i-- {
+		result.array = append(result.array, a.array[i])
+	}
+	return result
+}
+
+// Concat adds arrays to the end of the array and returns an new array
+func (a *Array[T]) Concat(arrays ...*Array[T]) *Array[T] {
+	result := New(a.array...)
+	for _, other := range arrays {
+		result.array = append(result.array, other.array...)
+	}
+	return result
+}
+
+// Sort sorts a copy of the array given a compare function
+func (a *Array[T]) Sort(compareFunc func(a, b T) bool) *Array[T] {
+	result := New(a.array...)
+	sort.SliceStable(result.array, func(i, j int) bool {
+		return compareFunc(result.array[i], result.array[j])
+	})
+	return result
+}
+
+// String returns a string representation of the array
+func (a *Array[T]) String() string {
+	return "Array" + fmt.Sprintf("%+v", a.array)
+}
+
+// Map iterate over array and push the result of callback into a new Array
+func Map[T, U any](a *Array[T], callback func(value T, i int) U) *Array[U] {
+	result := &Array[U]{array: make([]U, 0, len(a.array))}
+	for i, value := range a.array {
+		result.array = append(result.array, callback(value, i))
+	}
+	return result
+}
+
+// Reduce folds the array into a single value
+func Reduce[T, U any](a *Array[T], callback func(result U, value T, i int) U, initial U) U {
+	for i, value := range a.array {
+		initial = callback(initial, value, i)
+	}
+	return initial
+}
+
+// ReduceRight folds the array into a single value, starting from the end
+func ReduceRight[T, U any](a *Array[T], callback func(result U, value T, i int) U, initial U) U {
+	for i := len(a.array) - 1; i >= 0; i-- {
+		initial = callback(initial, a.array[i], i)
+	}
+	return initial
+}
+
+// IndexOf returns the first index of searchElement starting at fromIndex, or -1
+func IndexOf[T comparable](a *Array[T], searchElement T, fromIndex int) int {
+	for i := relativeIndex(fromIndex, len(a.array)); i < len(a.array); i++ {
+		if a.array[i] == searchElement {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexOf returns the last index of searchElement, searching backwards from fromIndex, or -1
+func LastIndexOf[T comparable](a *Array[T], searchElement T, fromIndex int) int {
+	if fromIndex < 0 {
+		fromIndex = len(a.array) + fromIndex
+	}
+	if fromIndex >= len(a.array) {
+		fromIndex = len(a.array) - 1
+	}
+	for i := fromIndex; i >= 0; i-- {
+		if a.array[i] == searchElement {
+			return i
+		}
+	}
+	return -1
+}
+
+// relativeIndex resolves a possibly negative index against length,
+// clamping the result to [0, length]
+func relativeIndex(index, length int) int {
+	if index < 0 {
+		index += length
+		if index < 0 {
+			return 0
+		}
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
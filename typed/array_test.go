@@ -0,0 +1,121 @@
+// Copyright 2015 mparaiso<mparaiso@online.fr>. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package typed
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/interactiv/datastruct/array"
+)
+
+func expect(t *testing.T, actual interface{}, expected interface{}) {
+	t.Helper()
+	if actual != expected {
+		t.Error(actual, "should be", expected)
+	}
+}
+
+func expectValues[T comparable](t *testing.T, a *Array[T], expected ...T) {
+	t.Helper()
+	if a.Length() != len(expected) {
+		t.Fatal(a, "should have length", len(expected))
+	}
+	for i, v := range expected {
+		actual, _ := a.At(i)
+		expect(t, actual, v)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	a := New[int]()
+	expect(t, a.Push(1, 2, 3), 3)
+	v, ok := a.Pop()
+	expect(t, v, 3)
+	expect(t, ok, true)
+	expectValues(t, a, 1, 2)
+	a.Pop()
+	a.Pop()
+	_, ok = a.Pop()
+	expect(t, ok, false)
+}
+
+func TestShiftUnshift(t *testing.T) {
+	a := New("baz")
+	a.Unshift("foo", "bar")
+	expectValues(t, a, "bar", "foo", "baz")
+	v, ok := a.Shift()
+	expect(t, v, "bar")
+	expect(t, ok, true)
+	a.Shift()
+	a.Shift()
+	_, ok = a.Shift()
+	expect(t, ok, false)
+}
+
+func TestAt(t *testing.T) {
+	a := New(1, 2)
+	_, ok := a.At(2)
+	expect(t, ok, false)
+	_, ok = a.At(-1)
+	expect(t, ok, false)
+}
+
+func TestSliceSplice(t *testing.T) {
+	a := New(1, 2, 3, 4, 5)
+	expectValues(t, a.Slice(1, 4), 2, 3, 4)
+	expectValues(t, a.Slice(-3), 3, 4, 5)
+	expectValues(t, a.Slice(0, -2), 1, 2, 3)
+	removed := a.Splice(1, 2, 7, 8, 9)
+	expectValues(t, removed, 2, 3)
+	expectValues(t, a, 1, 7, 8, 9, 4, 5)
+}
+
+func TestFilterMapReduce(t *testing.T) {
+	a := New(1, 2, 3, 4)
+	even := a.Filter(func(v int, i int) bool { return v%2 == 0 })
+	expectValues(t, even, 2, 4)
+	strs := Map(even, func(v int, i int) string { return strconv.Itoa(v) })
+	expectValues(t, strs, "2", "4")
+	expect(t, Reduce(a, func(r int, v int, i int) int { return r + v }, 0), 10)
+	expect(t, ReduceRight(strs, func(r string, v string, i int) string { return r + v }, ""), "42")
+}
+
+func TestSomeEveryReverseConcatSort(t *testing.T) {
+	a := New(3, 1, 2)
+	expect(t, a.Some(func(v int, i int) bool { return v > 2 }), true)
+	expect(t, a.Every(func(v int, i int) bool { return v > 2 }), false)
+	expectValues(t, a.Reverse(), 2, 1, 3)
+	expectValues(t, a.Concat(New(4), New(5)), 3, 1, 2, 4, 5)
+	expectValues(t, a.Sort(func(a, b int) bool { return a < b }), 1, 2, 3)
+	expectValues(t, a, 3, 1, 2)
+}
+
+func TestIndexOf(t *testing.T) {
+	a := New(1, 2, 3, 1)
+	expect(t, IndexOf(a, 1, 0), 0)
+	expect(t, IndexOf(a, 1, 1), 3)
+	expect(t, IndexOf(a, 4, 0), -1)
+	expect(t, LastIndexOf(a, 1, 3), 3)
+	expect(t, LastIndexOf(a, 1, 2), 0)
+}
+
+func TestArrayInterfaceConversion(t *testing.T) {
+	a := New(1, 2, 3)
+	untyped := a.ArrayInterface()
+	expect(t, untyped.Length(), 3)
+	expect(t, untyped.At(1), 2)
+
+	back, err := FromArrayInterface[int](untyped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectValues(t, back, 1, 2, 3)
+
+	_, err = FromArrayInterface[int](array.New(1, "two"))
+	if err == nil {
+		t.Error("FromArrayInterface should fail on mismatched element")
+	}
+}